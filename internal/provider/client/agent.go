@@ -12,7 +12,18 @@ type Agent struct {
 
 // AgentStatus - AgentStatus
 type AgentStatus struct {
-	BootstrapConfig *AgentBootstrapConfig `json:"bootstrapConfig,omitempty"`
+	BootstrapConfig  *AgentBootstrapConfig         `json:"bootstrapConfig,omitempty"`
+	LastHeartbeat    *string                       `json:"lastHeartbeat,omitempty"`
+	Connected        *bool                         `json:"connected,omitempty"`
+	Version          *string                       `json:"version,omitempty"`
+	PeerCount        *int                          `json:"peerCount,omitempty"`
+	NetworkResources *[]AgentNetworkResourceStatus `json:"networkResources,omitempty"`
+}
+
+// AgentNetworkResourceStatus - A network resource of an identity that is currently served by the agent
+type AgentNetworkResourceStatus struct {
+	IdentityLink *string `json:"identityLink,omitempty"`
+	Name         *string `json:"name,omitempty"`
 }
 
 type AgentBootstrapConfig struct {
@@ -1,6 +1,8 @@
 package cpln
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 )
 
@@ -56,17 +58,131 @@ type GCPServiceConnect struct {
 
 // AwsIdentity - AwsIdentity
 type AwsIdentity struct {
-	CloudAccountLink *string   `json:"cloudAccountLink,omitempty"`
-	PolicyRefs       *[]string `json:"policyRefs,omitempty"`
-	// TrustPolicy      *AwsPolicyDocument `json:"trustPolicy,omitempty"`
-	RoleName *string `json:"roleName,omitempty"`
+	CloudAccountLink *string            `json:"cloudAccountLink,omitempty"`
+	PolicyRefs       *[]string          `json:"policyRefs,omitempty"`
+	TrustPolicy      *AwsPolicyDocument `json:"trustPolicy,omitempty"`
+	InlinePolicy     *AwsPolicyDocument `json:"inlinePolicy,omitempty"`
+	RoleName         *string            `json:"roleName,omitempty"`
 }
 
-// // AwsPolicyDocument - AwsPolicyDocument
-// type AwsPolicyDocument struct {
-// 	Version   *string   `json:"version,omitempty"`
-// 	Statement *[]string `json:"statement,omitempty"`
-// }
+// AwsPolicyDocument - AwsPolicyDocument
+type AwsPolicyDocument struct {
+	Version   *string               `json:"Version,omitempty"`
+	Id        *string               `json:"Id,omitempty"`
+	Statement *[]AwsPolicyStatement `json:"Statement,omitempty"`
+}
+
+// AwsPolicyStatement - AwsPolicyStatement
+type AwsPolicyStatement struct {
+	Sid          *string                                         `json:"Sid,omitempty"`
+	Effect       *string                                         `json:"Effect,omitempty"`
+	Principal    *AwsPolicyPrincipal                             `json:"Principal,omitempty"`
+	NotPrincipal *AwsPolicyPrincipal                             `json:"NotPrincipal,omitempty"`
+	Action       *AwsPolicyStringList                            `json:"Action,omitempty"`
+	NotAction    *AwsPolicyStringList                            `json:"NotAction,omitempty"`
+	Resource     *AwsPolicyStringList                            `json:"Resource,omitempty"`
+	NotResource  *AwsPolicyStringList                            `json:"NotResource,omitempty"`
+	Condition    *map[string]map[string]AwsPolicyConditionValues `json:"Condition,omitempty"`
+}
+
+// AwsPolicyPrincipal - Principals by type. The anonymous principal "*" is held as the type "*" with the identifier "*"
+type AwsPolicyPrincipal map[string]AwsPolicyStringList
+
+func (p *AwsPolicyPrincipal) UnmarshalJSON(data []byte) error {
+
+	var single string
+
+	if err := json.Unmarshal(data, &single); err == nil {
+
+		if single != "*" {
+			return fmt.Errorf("a principal given as a string must be \"*\", got: %q", single)
+		}
+
+		*p = AwsPolicyPrincipal{"*": AwsPolicyStringList{"*"}}
+		return nil
+	}
+
+	var principals map[string]AwsPolicyStringList
+
+	if err := json.Unmarshal(data, &principals); err != nil {
+		return err
+	}
+
+	*p = principals
+
+	return nil
+}
+
+func (p AwsPolicyPrincipal) MarshalJSON() ([]byte, error) {
+
+	if identifiers, ok := p["*"]; ok && len(p) == 1 && len(identifiers) == 1 && identifiers[0] == "*" {
+		return json.Marshal("*")
+	}
+
+	return json.Marshal(map[string]AwsPolicyStringList(p))
+}
+
+// AwsPolicyStringList - AWS policy documents accept either a single string or a list of strings
+type AwsPolicyStringList []string
+
+func (l *AwsPolicyStringList) UnmarshalJSON(data []byte) error {
+
+	var single string
+
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = AwsPolicyStringList{single}
+		return nil
+	}
+
+	var list []string
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*l = list
+
+	return nil
+}
+
+// AwsPolicyConditionValues - Condition values may be strings, booleans or numbers, given alone or in a list. They are held in their string form
+type AwsPolicyConditionValues []string
+
+func (v *AwsPolicyConditionValues) UnmarshalJSON(data []byte) error {
+
+	var list []json.RawMessage
+
+	if err := json.Unmarshal(data, &list); err != nil {
+		list = []json.RawMessage{data}
+	}
+
+	values := AwsPolicyConditionValues{}
+
+	for _, item := range list {
+
+		var value interface{}
+
+		decoder := json.NewDecoder(bytes.NewReader(item))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		switch value := value.(type) {
+		case string:
+			values = append(values, value)
+		case bool, json.Number:
+			values = append(values, fmt.Sprintf("%v", value))
+		default:
+			return fmt.Errorf("a condition value must be a string, boolean or number, got: %s", item)
+		}
+	}
+
+	*v = values
+
+	return nil
+}
 
 type GcpBinding struct {
 	Resource *string   `json:"resource,omitempty"`
@@ -146,3 +262,153 @@ func (c *Client) UpdateIdentity(identity Identity, gvcName string) (*Identity, i
 func (c *Client) DeleteIdentity(name, gvcName string) error {
 	return c.DeleteResource(fmt.Sprintf("gvc/%s/identity/%s", gvcName, name))
 }
+
+/*** Identity Network Resources ***/
+
+// Tags recording which network resources of an identity are managed by their own resource rather than by the identity
+const (
+	IdentityNetworkResourceOwnerTagPrefix       = "terraform/networkResource/"
+	IdentityNativeNetworkResourceOwnerTagPrefix = "terraform/nativeNetworkResource/"
+)
+
+// AddIdentityNetworkResource - Add a network resource to an existing identity
+func (c *Client) AddIdentityNetworkResource(identityName, gvcName string, networkResource NetworkResource) (*Identity, int, error) {
+
+	return c.updateIdentityNetworkResources(identityName, gvcName, map[string]interface{}{IdentityNetworkResourceOwnerTagPrefix + *networkResource.Name: "true"}, func(identity *Identity) (int, error) {
+
+		for _, value := range *identity.NetworkResources {
+			if value.Name != nil && *value.Name == *networkResource.Name {
+				return 409, fmt.Errorf("network resource '%s' already exists in identity '%s'", *networkResource.Name, identityName)
+			}
+		}
+
+		*identity.NetworkResources = append(*identity.NetworkResources, networkResource)
+
+		return 0, nil
+	})
+}
+
+// UpdateIdentityNetworkResource - Replace a network resource of an existing identity, matched by name
+func (c *Client) UpdateIdentityNetworkResource(identityName, gvcName string, networkResource NetworkResource) (*Identity, int, error) {
+
+	return c.updateIdentityNetworkResources(identityName, gvcName, map[string]interface{}{IdentityNetworkResourceOwnerTagPrefix + *networkResource.Name: "true"}, func(identity *Identity) (int, error) {
+
+		for index, value := range *identity.NetworkResources {
+			if value.Name != nil && *value.Name == *networkResource.Name {
+				(*identity.NetworkResources)[index] = networkResource
+				return 0, nil
+			}
+		}
+
+		return 404, fmt.Errorf("network resource '%s' does not exist in identity '%s'", *networkResource.Name, identityName)
+	})
+}
+
+// RemoveIdentityNetworkResource - Remove a network resource from an existing identity by name
+func (c *Client) RemoveIdentityNetworkResource(identityName, gvcName, name string) (*Identity, int, error) {
+
+	return c.updateIdentityNetworkResources(identityName, gvcName, map[string]interface{}{IdentityNetworkResourceOwnerTagPrefix + name: nil}, func(identity *Identity) (int, error) {
+
+		for index, value := range *identity.NetworkResources {
+			if value.Name != nil && *value.Name == name {
+				*identity.NetworkResources = append((*identity.NetworkResources)[:index], (*identity.NetworkResources)[index+1:]...)
+				return 0, nil
+			}
+		}
+
+		return 404, fmt.Errorf("network resource '%s' does not exist in identity '%s'", name, identityName)
+	})
+}
+
+// AddIdentityNativeNetworkResource - Add a native network resource to an existing identity
+func (c *Client) AddIdentityNativeNetworkResource(identityName, gvcName string, nativeNetworkResource NativeNetworkResource) (*Identity, int, error) {
+
+	return c.updateIdentityNetworkResources(identityName, gvcName, map[string]interface{}{IdentityNativeNetworkResourceOwnerTagPrefix + *nativeNetworkResource.Name: "true"}, func(identity *Identity) (int, error) {
+
+		for _, value := range *identity.NativeNetworkResources {
+			if value.Name != nil && *value.Name == *nativeNetworkResource.Name {
+				return 409, fmt.Errorf("native network resource '%s' already exists in identity '%s'", *nativeNetworkResource.Name, identityName)
+			}
+		}
+
+		*identity.NativeNetworkResources = append(*identity.NativeNetworkResources, nativeNetworkResource)
+
+		return 0, nil
+	})
+}
+
+// UpdateIdentityNativeNetworkResource - Replace a native network resource of an existing identity, matched by name
+func (c *Client) UpdateIdentityNativeNetworkResource(identityName, gvcName string, nativeNetworkResource NativeNetworkResource) (*Identity, int, error) {
+
+	return c.updateIdentityNetworkResources(identityName, gvcName, map[string]interface{}{IdentityNativeNetworkResourceOwnerTagPrefix + *nativeNetworkResource.Name: "true"}, func(identity *Identity) (int, error) {
+
+		for index, value := range *identity.NativeNetworkResources {
+			if value.Name != nil && *value.Name == *nativeNetworkResource.Name {
+				(*identity.NativeNetworkResources)[index] = nativeNetworkResource
+				return 0, nil
+			}
+		}
+
+		return 404, fmt.Errorf("native network resource '%s' does not exist in identity '%s'", *nativeNetworkResource.Name, identityName)
+	})
+}
+
+// RemoveIdentityNativeNetworkResource - Remove a native network resource from an existing identity by name
+func (c *Client) RemoveIdentityNativeNetworkResource(identityName, gvcName, name string) (*Identity, int, error) {
+
+	return c.updateIdentityNetworkResources(identityName, gvcName, map[string]interface{}{IdentityNativeNetworkResourceOwnerTagPrefix + name: nil}, func(identity *Identity) (int, error) {
+
+		for index, value := range *identity.NativeNetworkResources {
+			if value.Name != nil && *value.Name == name {
+				*identity.NativeNetworkResources = append((*identity.NativeNetworkResources)[:index], (*identity.NativeNetworkResources)[index+1:]...)
+				return 0, nil
+			}
+		}
+
+		return 404, fmt.Errorf("native network resource '%s' does not exist in identity '%s'", name, identityName)
+	})
+}
+
+// ClaimIdentityNetworkResource - Record that an existing network resource of the identity is managed by its own resource.
+// The prefix selects the network resource kind, see IdentityNetworkResourceOwnerTagPrefix and IdentityNativeNetworkResourceOwnerTagPrefix
+func (c *Client) ClaimIdentityNetworkResource(identityName, gvcName, prefix, name string) (*Identity, int, error) {
+
+	identityToUpdate := Identity{}
+	identityToUpdate.Name = &identityName
+	identityToUpdate.Tags = &map[string]interface{}{
+		prefix + name: "true",
+	}
+
+	return c.UpdateIdentity(identityToUpdate, gvcName)
+}
+
+// updateIdentityNetworkResources - Fetch the identity, apply the change to its network resources and write both lists back
+// together with the given owner tags. Callers are expected to serialize calls for the same identity, since the lists are replaced as a whole
+func (c *Client) updateIdentityNetworkResources(identityName, gvcName string, tags map[string]interface{}, apply func(identity *Identity) (int, error)) (*Identity, int, error) {
+
+	identity, code, err := c.GetIdentity(identityName, gvcName)
+
+	if err != nil {
+		return nil, code, err
+	}
+
+	if identity.NetworkResources == nil {
+		identity.NetworkResources = &[]NetworkResource{}
+	}
+
+	if identity.NativeNetworkResources == nil {
+		identity.NativeNetworkResources = &[]NativeNetworkResource{}
+	}
+
+	if code, err := apply(identity); err != nil {
+		return nil, code, err
+	}
+
+	identityToUpdate := Identity{}
+	identityToUpdate.Name = identity.Name
+	identityToUpdate.Tags = &tags
+	identityToUpdate.NetworkResources = identity.NetworkResources
+	identityToUpdate.NativeNetworkResources = identity.NativeNetworkResources
+
+	return c.UpdateIdentity(identityToUpdate, gvcName)
+}
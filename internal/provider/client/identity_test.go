@@ -0,0 +1,60 @@
+package cpln
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+/*** Unit Tests ***/
+func TestControlPlane_IdentityNetworkResourceOwnerTags(t *testing.T) {
+
+	var patches []map[string]interface{}
+
+	c, server := newTestServiceAccountClient(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			patch := map[string]interface{}{}
+			json.Unmarshal(body, &patch)
+			patches = append(patches, patch)
+		}
+
+		w.Write([]byte(`{"name":"identity-example","networkResources":[{"name":"app-db","fqdn":"db.example.com"}]}`))
+	})
+	defer server.Close()
+
+	name := "team-cache"
+	fqdn := "cache.example.com"
+
+	if _, _, err := c.AddIdentityNetworkResource("identity-example", "gvc-example", NetworkResource{Name: &name, FQDN: &fqdn}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, _, err := c.RemoveIdentityNetworkResource("identity-example", "gvc-example", "app-db"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, _, err := c.ClaimIdentityNetworkResource("identity-example", "gvc-example", IdentityNativeNetworkResourceOwnerTagPrefix, "bucket"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedTags := []interface{}{
+		map[string]interface{}{IdentityNetworkResourceOwnerTagPrefix + "team-cache": "true"},
+		map[string]interface{}{IdentityNetworkResourceOwnerTagPrefix + "app-db": nil},
+		map[string]interface{}{IdentityNativeNetworkResourceOwnerTagPrefix + "bucket": "true"},
+	}
+
+	tags := []interface{}{}
+
+	for _, patch := range patches {
+		tags = append(tags, patch["tags"])
+	}
+
+	if diff := deep.Equal(tags, expectedTags); diff != nil {
+		t.Errorf("Owner tags were not sent correctly. Diff: %s", diff)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -27,13 +28,23 @@ type Location struct {
 
 // LocationSpec
 type LocationSpec struct {
-	Enabled *bool `json:"enabled,omitempty"`
+	Enabled *bool        `json:"enabled,omitempty"`
+	Geo     *LocationGeo `json:"geo,omitempty"`
 }
 
 // LocationStatus
 type LocationStatus struct {
-	Geo      *LocationGeo `json:"geo,omitempty"`
-	IpRanges *[]string    `json:"ipRanges,omitempty"`
+	Geo             *LocationGeo             `json:"geo,omitempty"`
+	IpRanges        *[]string                `json:"ipRanges,omitempty"`
+	BootstrapConfig *LocationBootstrapConfig `json:"bootstrapConfig,omitempty"`
+}
+
+// LocationBootstrapConfig - Configuration used to install a custom location on a cluster
+type LocationBootstrapConfig struct {
+	RegistrationToken *string `json:"registrationToken,omitempty"`
+	LocationId        *string `json:"locationId,omitempty"`
+	LocationLink      *string `json:"locationLink,omitempty"`
+	HubEndpoint       *string `json:"hubEndpoint,omitempty"`
 }
 
 // LocationStatus
@@ -215,3 +226,47 @@ func (c *Client) GetLocations() (*Locations, error) {
 
 	return &locations, nil
 }
+
+// UpdateLocation
+func (c *Client) UpdateLocation(location Location) (*Location, int, error) {
+
+	code, err := c.UpdateResource(fmt.Sprintf("location/%s", *location.Name), location)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return c.GetLocation(*location.Name)
+}
+
+// CreateCustomLocation - Create a custom location. The bootstrap config is only returned on creation
+func (c *Client) CreateCustomLocation(location Location) (*Location, int, error) {
+
+	l, err := json.Marshal(location)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/org/%s/location", c.HostURL, c.Org), strings.NewReader(string(l)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body, code, err := c.doRequest(req, "application/json")
+	if err != nil {
+		return nil, code, err
+	}
+
+	output := Location{}
+
+	err = json.Unmarshal(body, &output)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return &output, code, nil
+}
+
+// DeleteLocation
+func (c *Client) DeleteLocation(name string) error {
+	return c.DeleteResource(fmt.Sprintf("location/%s", name))
+}
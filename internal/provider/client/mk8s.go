@@ -0,0 +1,230 @@
+package cpln
+
+import "fmt"
+
+// Mk8s - Managed Kubernetes Cluster
+type Mk8s struct {
+	Base
+	Spec        *Mk8sSpec   `json:"spec,omitempty"`
+	SpecReplace *Mk8sSpec   `json:"$replace/spec,omitempty"`
+	Status      *Mk8sStatus `json:"status,omitempty"`
+}
+
+// Mk8sSpec - Managed Kubernetes Cluster Spec
+type Mk8sSpec struct {
+	Version  *string         `json:"version,omitempty"`
+	Firewall *[]Mk8sFirewall `json:"firewall,omitempty"`
+	Provider *Mk8sProvider   `json:"provider,omitempty"`
+	AddOns   *Mk8sAddOns     `json:"addOns,omitempty"`
+}
+
+// Mk8sFirewall - Firewall Rule
+type Mk8sFirewall struct {
+	SourceCIDR  *string `json:"sourceCIDR,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// Mk8sProvider - Exactly one provider must be set
+type Mk8sProvider struct {
+	Generic *Mk8sGenericProvider `json:"generic,omitempty"`
+	Hetzner *Mk8sHetznerProvider `json:"hetzner,omitempty"`
+	Aws     *Mk8sAwsProvider     `json:"aws,omitempty"`
+}
+
+// Mk8sGenericProvider - Generic Provider
+type Mk8sGenericProvider struct {
+	Location   *string         `json:"location,omitempty"`
+	Networking *Mk8sNetworking `json:"networking,omitempty"`
+	NodePools  *[]Mk8sNodePool `json:"nodePools,omitempty"`
+}
+
+// Mk8sHetznerProvider - Hetzner Provider
+type Mk8sHetznerProvider struct {
+	Region           *string                 `json:"region,omitempty"`
+	HetznerLabels    *map[string]interface{} `json:"hetznerLabels,omitempty"`
+	Networking       *Mk8sNetworking         `json:"networking,omitempty"`
+	PreInstallScript *string                 `json:"preInstallScript,omitempty"`
+	TokenSecretLink  *string                 `json:"tokenSecretLink,omitempty"`
+	NetworkId        *string                 `json:"networkId,omitempty"`
+	NodePools        *[]Mk8sHetznerPool      `json:"nodePools,omitempty"`
+	Image            *string                 `json:"image,omitempty"`
+	SshKey           *string                 `json:"sshKey,omitempty"`
+	Autoscaler       *Mk8sAutoscaler         `json:"autoscaler,omitempty"`
+}
+
+// Mk8sAwsProvider - AWS Provider
+type Mk8sAwsProvider struct {
+	Region               *string                 `json:"region,omitempty"`
+	AwsTags              *map[string]interface{} `json:"awsTags,omitempty"`
+	SkipCreateRoles      *bool                   `json:"skipCreateRoles,omitempty"`
+	Networking           *Mk8sNetworking         `json:"networking,omitempty"`
+	PreInstallScript     *string                 `json:"preInstallScript,omitempty"`
+	Image                *Mk8sAwsAmi             `json:"image,omitempty"`
+	DeployRoleArn        *string                 `json:"deployRoleArn,omitempty"`
+	VpcId                *string                 `json:"vpcId,omitempty"`
+	KeyPair              *string                 `json:"keyPair,omitempty"`
+	DiskEncryptionKeyArn *string                 `json:"diskEncryptionKeyArn,omitempty"`
+	SecurityGroupIds     *[]string               `json:"securityGroupIds,omitempty"`
+	NodePools            *[]Mk8sAwsPool          `json:"nodePools,omitempty"`
+	Autoscaler           *Mk8sAutoscaler         `json:"autoscaler,omitempty"`
+}
+
+// Mk8sNetworking - Networking
+type Mk8sNetworking struct {
+	ServiceNetwork *string `json:"serviceNetwork,omitempty"`
+	PodNetwork     *string `json:"podNetwork,omitempty"`
+}
+
+// Mk8sNodePool - Generic Node Pool
+type Mk8sNodePool struct {
+	Name   *string                 `json:"name,omitempty"`
+	Labels *map[string]interface{} `json:"labels,omitempty"`
+	Taints *[]Mk8sTaint            `json:"taints,omitempty"`
+}
+
+// Mk8sHetznerPool - Hetzner Node Pool
+type Mk8sHetznerPool struct {
+	Mk8sNodePool
+	ServerType    *string `json:"serverType,omitempty"`
+	OverrideImage *string `json:"overrideImage,omitempty"`
+	MinSize       *int    `json:"minSize,omitempty"`
+	MaxSize       *int    `json:"maxSize,omitempty"`
+}
+
+// Mk8sAwsPool - AWS Node Pool
+type Mk8sAwsPool struct {
+	Mk8sNodePool
+	InstanceTypes                       *[]string   `json:"instanceTypes,omitempty"`
+	OverrideImage                       *Mk8sAwsAmi `json:"overrideImage,omitempty"`
+	BootDiskSize                        *int        `json:"bootDiskSize,omitempty"`
+	MinSize                             *int        `json:"minSize,omitempty"`
+	MaxSize                             *int        `json:"maxSize,omitempty"`
+	OnDemandBaseCapacity                *int        `json:"onDemandBaseCapacity,omitempty"`
+	OnDemandPercentageAboveBaseCapacity *int        `json:"onDemandPercentageAboveBaseCapacity,omitempty"`
+	SpotAllocationStrategy              *string     `json:"spotAllocationStrategy,omitempty"`
+	SubnetIds                           *[]string   `json:"subnetIds,omitempty"`
+	ExtraSecurityGroupIds               *[]string   `json:"extraSecurityGroupIds,omitempty"`
+}
+
+// Mk8sTaint - Node Taint
+type Mk8sTaint struct {
+	Key    *string `json:"key,omitempty"`
+	Value  *string `json:"value,omitempty"`
+	Effect *string `json:"effect,omitempty"`
+}
+
+// Mk8sAwsAmi - Either a recommended or an exact AMI
+type Mk8sAwsAmi struct {
+	Recommended *string `json:"recommended,omitempty"`
+	Exact       *string `json:"exact,omitempty"`
+}
+
+// Mk8sAutoscaler - Cluster Autoscaler
+type Mk8sAutoscaler struct {
+	Expander             *[]string `json:"expander,omitempty"`
+	UnneededTime         *string   `json:"unneededTime,omitempty"`
+	UnreadyTime          *string   `json:"unreadyTime,omitempty"`
+	UtilizationThreshold *float64  `json:"utilizationThreshold,omitempty"`
+}
+
+// Mk8sAddOns - Add-ons
+type Mk8sAddOns struct {
+	Dashboard *Mk8sNonCustomizableAddOn `json:"dashboard,omitempty"`
+	Logs      *Mk8sLogsAddOn            `json:"logs,omitempty"`
+	Metrics   *Mk8sMetricsAddOn         `json:"metrics,omitempty"`
+}
+
+// Mk8sNonCustomizableAddOn - Add-on that is enabled by its presence
+type Mk8sNonCustomizableAddOn struct{}
+
+// Mk8sLogsAddOn - Logs Add-on
+type Mk8sLogsAddOn struct {
+	AuditEnabled      *bool   `json:"auditEnabled,omitempty"`
+	IncludeNamespaces *string `json:"includeNamespaces,omitempty"`
+	ExcludeNamespaces *string `json:"excludeNamespaces,omitempty"`
+}
+
+// Mk8sMetricsAddOn - Metrics Add-on
+type Mk8sMetricsAddOn struct {
+	KubeState    *bool `json:"kubeState,omitempty"`
+	CoreDns      *bool `json:"coreDns,omitempty"`
+	Kubelet      *bool `json:"kubelet,omitempty"`
+	Apiserver    *bool `json:"apiserver,omitempty"`
+	NodeExporter *bool `json:"nodeExporter,omitempty"`
+	Cadvisor     *bool `json:"cadvisor,omitempty"`
+}
+
+// Mk8sStatus - Managed Kubernetes Cluster Status
+type Mk8sStatus struct {
+	OidcProviderUrl *string           `json:"oidcProviderUrl,omitempty"`
+	ServerUrl       *string           `json:"serverUrl,omitempty"`
+	HomeLocation    *string           `json:"homeLocation,omitempty"`
+	AddOns          *Mk8sAddOnsStatus `json:"addOns,omitempty"`
+}
+
+// Mk8sAddOnsStatus - Add-ons Status
+type Mk8sAddOnsStatus struct {
+	Dashboard *Mk8sDashboardAddOnStatus `json:"dashboard,omitempty"`
+	Logs      *Mk8sLogsAddOnStatus      `json:"logs,omitempty"`
+	Metrics   *Mk8sMetricsAddOnStatus   `json:"metrics,omitempty"`
+}
+
+// Mk8sAddOnHealth - Health reported for an Add-on
+type Mk8sAddOnHealth struct {
+	Health      *string `json:"health,omitempty"`
+	Message     *string `json:"message,omitempty"`
+	LastChecked *string `json:"lastChecked,omitempty"`
+}
+
+// Mk8sDashboardAddOnStatus - Dashboard Add-on Status
+type Mk8sDashboardAddOnStatus struct {
+	Mk8sAddOnHealth
+	Url *string `json:"url,omitempty"`
+}
+
+// Mk8sLogsAddOnStatus - Logs Add-on Status
+type Mk8sLogsAddOnStatus struct {
+	Mk8sAddOnHealth
+	LokiAddress *string `json:"lokiAddress,omitempty"`
+}
+
+// Mk8sMetricsAddOnStatus - Metrics Add-on Status
+type Mk8sMetricsAddOnStatus struct {
+	Mk8sAddOnHealth
+	PrometheusEndpoint *string      `json:"prometheusEndpoint,omitempty"`
+	RemoteWriteConfig  *interface{} `json:"remoteWriteConfig,omitempty"`
+}
+
+func (c *Client) GetMk8s(name string) (*Mk8s, int, error) {
+
+	mk8s, code, err := c.GetResource(fmt.Sprintf("mk8s/%s", name), new(Mk8s))
+	if err != nil {
+		return nil, code, err
+	}
+
+	return mk8s.(*Mk8s), code, err
+}
+
+func (c *Client) CreateMk8s(mk8s Mk8s) (*Mk8s, int, error) {
+
+	code, err := c.CreateResource("mk8s", *mk8s.Name, mk8s)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return c.GetMk8s(*mk8s.Name)
+}
+
+func (c *Client) UpdateMk8s(mk8s Mk8s) (*Mk8s, int, error) {
+
+	code, err := c.UpdateResource(fmt.Sprintf("mk8s/%s", *mk8s.Name), mk8s)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return c.GetMk8s(*mk8s.Name)
+}
+
+func (c *Client) DeleteMk8s(name string) error {
+	return c.DeleteResource(fmt.Sprintf("mk8s/%s", name))
+}
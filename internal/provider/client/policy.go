@@ -3,8 +3,17 @@ package cpln
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 )
 
+// Policies - Org Policies
+type Policies struct {
+	Kind     string   `json:"kind,omitempty"`
+	ItemKind string   `json:"itemKind,omitempty"`
+	Links    []Link   `json:"links,omitempty"`
+	Items    []Policy `json:"items,omitempty"`
+}
+
 // Policy - Policy
 type Policy struct {
 	Base
@@ -51,6 +60,28 @@ type Binding struct {
 	PrincipalLinks *[]string `json:"principalLinks,omitempty"`
 }
 
+// GetPolicies - Get all the policies of the org
+func (c *Client) GetPolicies() (*[]Policy, int, error) {
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/org/%s/policy", c.HostURL, c.Org), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body, code, err := c.doRequest(req, "")
+	if err != nil {
+		return nil, code, err
+	}
+
+	policies := Policies{}
+	err = json.Unmarshal(body, &policies)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &policies.Items, code, nil
+}
+
 // GetPolicy - Get Policy by name
 func (c *Client) GetPolicy(name string) (*Policy, int, error) {
 
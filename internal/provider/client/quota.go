@@ -0,0 +1,60 @@
+package cpln
+
+import "fmt"
+
+// Quota - Quota
+type Quota struct {
+	Base
+	Spec        *QuotaSpec   `json:"spec,omitempty"`
+	SpecReplace *QuotaSpec   `json:"$replace/spec,omitempty"`
+	Status      *QuotaStatus `json:"status,omitempty"`
+}
+
+// QuotaSpec - Quota Spec
+type QuotaSpec struct {
+	Dimension   *string   `json:"dimension,omitempty"`
+	Max         *float64  `json:"max,omitempty"`
+	Unit        *string   `json:"unit,omitempty"`
+	TargetKind  *string   `json:"targetKind,omitempty"`
+	TargetLinks *[]string `json:"targetLinks,omitempty"`
+	TargetQuery *Query    `json:"targetQuery,omitempty"`
+}
+
+// QuotaStatus - Quota Status
+type QuotaStatus struct {
+	Current *float64 `json:"current,omitempty"`
+}
+
+func (c *Client) GetQuota(name string) (*Quota, int, error) {
+
+	quota, code, err := c.GetResource(fmt.Sprintf("quota/%s", name), new(Quota))
+	if err != nil {
+		return nil, code, err
+	}
+
+	return quota.(*Quota), code, err
+}
+
+func (c *Client) CreateQuota(quota Quota) (*Quota, int, error) {
+
+	code, err := c.CreateResource("quota", *quota.Name, quota)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return c.GetQuota(*quota.Name)
+}
+
+func (c *Client) UpdateQuota(quota Quota) (*Quota, int, error) {
+
+	code, err := c.UpdateResource(fmt.Sprintf("quota/%s", *quota.Name), quota)
+	if err != nil {
+		return nil, code, err
+	}
+
+	return c.GetQuota(*quota.Name)
+}
+
+func (c *Client) DeleteQuota(name string) error {
+	return c.DeleteResource(fmt.Sprintf("quota/%s", name))
+}
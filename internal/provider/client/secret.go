@@ -24,6 +24,18 @@ func (c *Client) GetSecret(name string) (*Secret, int, error) {
 	return secret.(*Secret), code, err
 }
 
+// GetSecretMetadata - Get secret by name without revealing its data
+func (c *Client) GetSecretMetadata(name string) (*Secret, int, error) {
+
+	secret, code, err := c.GetResource(fmt.Sprintf("secret/%s", name), new(Secret))
+
+	if err != nil {
+		return nil, code, err
+	}
+
+	return secret.(*Secret), code, err
+}
+
 // CreateSecret - Create a new Secret
 func (c *Client) CreateSecret(secret Secret) (*Secret, int, error) {
 
@@ -79,31 +79,45 @@ func (c *Client) AddServiceAccountKey(serviceAccountName, description string) (*
 	return &saKey, nil
 }
 
-// RemoveServiceAccountKey = Remove Service Account Key
-func (c *Client) RemoveServiceAccountKey(serviceAccountName, keyName string) error {
+// ListServiceAccountKeys - List the keys of a Service Account
+func (c *Client) ListServiceAccountKeys(serviceAccountName string) ([]ServiceAccountKey, int, error) {
 
-	removeKey := make(map[string][]string)
+	serviceAccount, code, err := c.GetServiceAccount(serviceAccountName)
 
-	removeKey["$drop/keys"] = []string{
-		keyName,
+	if err != nil {
+		return nil, code, err
+	}
+
+	if serviceAccount.Keys == nil {
+		return []ServiceAccountKey{}, code, nil
 	}
 
-	s, err := json.Marshal(removeKey)
+	return *serviceAccount.Keys, code, nil
+}
+
+// RemoveServiceAccountKey - Remove a Service Account Key by name
+func (c *Client) RemoveServiceAccountKey(serviceAccountName, keyName string) (int, error) {
+
+	key := make(map[string]string)
+
+	key["name"] = keyName
+
+	s, err := json.Marshal(key)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	req, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/org/%s/serviceaccount/%s", c.HostURL, c.Org, serviceAccountName), strings.NewReader(string(s)))
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/org/%s/serviceaccount/%s/-removeKey", c.HostURL, c.Org, serviceAccountName), strings.NewReader(string(s)))
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	_, _, err = c.doRequest(req, "application/json")
+	_, code, err := c.doRequest(req, "application/json")
 	if err != nil {
-		return err
+		return code, err
 	}
 
-	return nil
+	return code, nil
 }
 
 // UpdateServiceAccount - Update an existing ServiceAccount
@@ -0,0 +1,117 @@
+package cpln
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+/*** Unit Tests ***/
+func TestControlPlane_ListServiceAccountKeys(t *testing.T) {
+
+	c, server := newTestServiceAccountClient(func(w http.ResponseWriter, r *http.Request) {
+
+		switch r.URL.Path {
+		case "/org/example/serviceaccount/with-keys":
+			w.Write([]byte(`{"name":"with-keys","keys":[{"name":"key-01","created":"2024-05-01T10:00:00.000Z","description":"First key"}]}`))
+		case "/org/example/serviceaccount/without-keys":
+			w.Write([]byte(`{"name":"without-keys"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	})
+	defer server.Close()
+
+	keys, _, err := c.ListServiceAccountKeys("with-keys")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	created := "2024-05-01T10:00:00.000Z"
+	description := "First key"
+	expectedKeys := []ServiceAccountKey{
+		{
+			Name:        "key-01",
+			Created:     &created,
+			Description: &description,
+		},
+	}
+
+	if diff := deep.Equal(keys, expectedKeys); diff != nil {
+		t.Errorf("Service account keys were not listed correctly. Diff: %s", diff)
+	}
+
+	keys, _, err = c.ListServiceAccountKeys("without-keys")
+
+	if err != nil || keys == nil || len(keys) != 0 {
+		t.Errorf("A service account without keys should return an empty list, got: %v, error: %v", keys, err)
+	}
+
+	if _, code, err := c.ListServiceAccountKeys("missing"); err == nil || code != http.StatusNotFound {
+		t.Errorf("A missing service account should return a 404 error, got code: %d", code)
+	}
+}
+
+func TestControlPlane_RemoveServiceAccountKey(t *testing.T) {
+
+	var requestMethod, requestPath, requestAuthorization string
+	var requestBody map[string]string
+
+	c, server := newTestServiceAccountClient(func(w http.ResponseWriter, r *http.Request) {
+
+		requestMethod = r.Method
+		requestPath = r.URL.Path
+		requestAuthorization = r.Header.Get("Authorization")
+
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &requestBody)
+
+		if requestBody["name"] == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"key not found"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+	defer server.Close()
+
+	if _, err := c.RemoveServiceAccountKey("service-account-example", "key-01"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if requestMethod != http.MethodPost || requestPath != "/org/example/serviceaccount/service-account-example/-removeKey" {
+		t.Errorf("Unexpected request: %s %s", requestMethod, requestPath)
+	}
+
+	if requestAuthorization != "test-token" {
+		t.Errorf("Request was not authorized with the client token, got: %s", requestAuthorization)
+	}
+
+	if diff := deep.Equal(requestBody, map[string]string{"name": "key-01"}); diff != nil {
+		t.Errorf("Unexpected request body. Diff: %s", diff)
+	}
+
+	if code, err := c.RemoveServiceAccountKey("service-account-example", "missing"); err == nil || code != http.StatusNotFound {
+		t.Errorf("Removing a missing key should return a 404 error, got code: %d", code)
+	}
+}
+
+/*** Helpers ***/
+func newTestServiceAccountClient(handler http.HandlerFunc) (*Client, *httptest.Server) {
+
+	server := httptest.NewServer(handler)
+
+	return &Client{
+		HostURL:    server.URL,
+		Org:        "example",
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+	}, server
+}
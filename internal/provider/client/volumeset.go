@@ -18,10 +18,36 @@ type VolumeSetSpec struct {
 }
 
 type VolumeSetStatus struct {
-	ParentID       *string        `json:"parentID,omitempty"`
-	UsedByWorkload *string        `json:"usedByWorkload,omitempty"`
-	BindingID      *string        `json:"bindingId,omitempty"`
-	Locations      *[]interface{} `json:"locations,omitempty"`
+	ParentID       *string              `json:"parentID,omitempty"`
+	UsedByWorkload *string              `json:"usedByWorkload,omitempty"`
+	BindingID      *string              `json:"bindingId,omitempty"`
+	Locations      *[]VolumeSetLocation `json:"locations,omitempty"`
+}
+
+type VolumeSetLocation struct {
+	Name    *string            `json:"name,omitempty"`
+	Volumes *[]VolumeSetVolume `json:"volumes,omitempty"`
+}
+
+type VolumeSetVolume struct {
+	Index            *int                       `json:"index,omitempty"`
+	Lifecycle        *string                    `json:"lifecycle,omitempty"`
+	StorageDeviceId  *string                    `json:"storageDeviceId,omitempty"`
+	ResourceName     *string                    `json:"resourceName,omitempty"`
+	Zone             *string                    `json:"zone,omitempty"`
+	CurrentSize      *int                       `json:"currentSize,omitempty"`
+	CurrentBytesUsed *int64                     `json:"currentBytesUsed,omitempty"`
+	Iops             *int                       `json:"iops,omitempty"`
+	Throughput       *int                       `json:"throughput,omitempty"`
+	VolumeSnapshots  *[]VolumeSetVolumeSnapshot `json:"volumeSnapshots,omitempty"`
+}
+
+type VolumeSetVolumeSnapshot struct {
+	Id      *string `json:"id,omitempty"`
+	Name    *string `json:"name,omitempty"`
+	Created *string `json:"created,omitempty"`
+	Expires *string `json:"expires,omitempty"`
+	Size    *int    `json:"size,omitempty"`
 }
 
 type VolumeSetSnapshots struct {
@@ -46,6 +72,44 @@ func (c *Client) GetVolumeSet(name string, gvc string) (*VolumeSet, int, error)
 	return volumeSet.(*VolumeSet), code, err
 }
 
+type VolumeSetCommand struct {
+	Type *string                 `json:"type,omitempty"`
+	Spec *VolumeSetCommandVolume `json:"spec,omitempty"`
+}
+
+type VolumeSetCommandVolume struct {
+	Location               *string            `json:"location,omitempty"`
+	VolumeIndex            *int               `json:"volumeIndex"`
+	SnapshotName           *string            `json:"snapshotName,omitempty"`
+	SnapshotExpirationDate *string            `json:"snapshotExpirationDate,omitempty"`
+	SnapshotTags           *map[string]string `json:"snapshotTags,omitempty"`
+}
+
+// CreateVolumeSetSnapshot - Take an on-demand snapshot of a single volume of a volume set
+func (c *Client) CreateVolumeSetSnapshot(name string, gvc string, volume VolumeSetCommandVolume) (int, error) {
+	return c.runVolumeSetCommand(name, gvc, "createVolumeSnapshot", volume)
+}
+
+// DeleteVolumeSetSnapshot - Delete a snapshot of a single volume of a volume set
+func (c *Client) DeleteVolumeSetSnapshot(name string, gvc string, volume VolumeSetCommandVolume) (int, error) {
+	return c.runVolumeSetCommand(name, gvc, "deleteVolumeSnapshot", volume)
+}
+
+// RestoreVolumeSetVolume - Restore a single volume of a volume set from one of its snapshots
+func (c *Client) RestoreVolumeSetVolume(name string, gvc string, volume VolumeSetCommandVolume) (int, error) {
+	return c.runVolumeSetCommand(name, gvc, "restoreVolume", volume)
+}
+
+func (c *Client) runVolumeSetCommand(name string, gvc string, commandType string, volume VolumeSetCommandVolume) (int, error) {
+
+	command := VolumeSetCommand{
+		Type: &commandType,
+		Spec: &volume,
+	}
+
+	return c.CreateResource(fmt.Sprintf("gvc/%s/volumeset/%s/-command", gvc, name), commandType, command)
+}
+
 func (c *Client) CreateVolumeSet(volumeSet VolumeSet, gvc string) (*VolumeSet, int, error) {
 
 	code, err := c.CreateResource(fmt.Sprintf("gvc/%s/volumeset", gvc), *volumeSet.Name, volumeSet)
@@ -24,17 +24,22 @@ type Workload struct {
 
 // WorkloadSpec - Workload Specifications
 type WorkloadSpec struct {
-	Type               *string          `json:"type,omitempty"`
-	IdentityLink       *string          `json:"identityLink,omitempty"`
-	Containers         *[]ContainerSpec `json:"containers,omitempty"`
-	FirewallConfig     *FirewallSpec    `json:"firewallConfig,omitempty"`
-	DefaultOptions     *Options         `json:"defaultOptions,omitempty"`
-	LocalOptions       *[]Options       `json:"localOptions,omitempty"`
-	RolloutOptions     *RolloutOptions  `json:"rolloutOptions,omitempty"`
-	Job                *JobSpec         `json:"job,omitempty"`
-	SecurityOptions    *SecurityOptions `json:"securityOptions,omitempty"`
-	SupportDynamicTags *bool            `json:"supportDynamicTags,omitempty"`
-	Sidecar            *WorkloadSidecar `json:"sidecar,omitempty"`
+	Type               *string               `json:"type,omitempty"`
+	IdentityLink       *string               `json:"identityLink,omitempty"`
+	Containers         *[]ContainerSpec      `json:"containers,omitempty"`
+	InitContainers     *[]ContainerSpec      `json:"initContainers,omitempty"`
+	FirewallConfig     *FirewallSpec         `json:"firewallConfig,omitempty"`
+	DefaultOptions     *Options              `json:"defaultOptions,omitempty"`
+	LocalOptions       *[]Options            `json:"localOptions,omitempty"`
+	RolloutOptions     *RolloutOptions       `json:"rolloutOptions,omitempty"`
+	Job                *JobSpec              `json:"job,omitempty"`
+	SecurityOptions    *SecurityOptions      `json:"securityOptions,omitempty"`
+	SupportDynamicTags *bool                 `json:"supportDynamicTags,omitempty"`
+	Sidecar            *WorkloadSidecar      `json:"sidecar,omitempty"`
+	LoadBalancer       *WorkloadLoadBalancer `json:"loadBalancer,omitempty"`
+	RequestRetryPolicy *RequestRetryPolicy   `json:"requestRetryPolicy,omitempty"`
+	Routes             *[]WorkloadRoute      `json:"routes,omitempty"`
+	Mirror             *[]WorkloadMirror     `json:"mirror,omitempty"`
 }
 
 // ContainerSpec - Workload Container Definition
@@ -47,6 +52,8 @@ type ContainerSpec struct {
 	ReadinessProbe   *HealthCheckSpec `json:"readinessProbe,omitempty"`
 	LivenessProbe    *HealthCheckSpec `json:"livenessProbe,omitempty"`
 	CPU              *string          `json:"cpu,omitempty"`
+	MinCPU           *string          `json:"minCpu,omitempty"`
+	MinMemory        *string          `json:"minMemory,omitempty"`
 	GPU              *GpuResource     `json:"gpu,omitempty"`
 	Env              *[]NameValue     `json:"env,omitempty"`
 	Args             *[]string        `json:"args,omitempty"`
@@ -82,23 +89,80 @@ type PortSpec struct {
 
 // Options - Options
 type Options struct {
-	AutoScaling    *AutoScaling `json:"autoscaling,omitempty"`
-	CapacityAI     *bool        `json:"capacityAI,omitempty"`
-	TimeoutSeconds *int         `json:"timeoutSeconds,omitempty"`
-	Debug          *bool        `json:"debug,omitempty"`
-	Suspend        *bool        `json:"suspend,omitempty"`
-	Location       *string      `json:"location,omitempty"`
+	AutoScaling    *AutoScaling  `json:"autoscaling,omitempty"`
+	CapacityAI     *bool         `json:"capacityAI,omitempty"`
+	TimeoutSeconds *int          `json:"timeoutSeconds,omitempty"`
+	Debug          *bool         `json:"debug,omitempty"`
+	Suspend        *bool         `json:"suspend,omitempty"`
+	Location       *string       `json:"location,omitempty"`
+	MultiZone      *MultiZone    `json:"multiZone,omitempty"`
+	Spot           *SpotOptions  `json:"spot,omitempty"`
+	NodeAffinity   *NodeAffinity `json:"nodeAffinity,omitempty"`
+}
+
+// MultiZone - Spread replicas across the availability zones of a location
+type MultiZone struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// SpotOptions - Spot Node Preference
+type SpotOptions struct {
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Preference *string `json:"preference,omitempty"`
+}
+
+// NodeAffinity - Node Placement Constraints
+type NodeAffinity struct {
+	Required  *[]NodeSelectorRequirement `json:"required,omitempty"`
+	Preferred *[]PreferredNodeAffinity   `json:"preferred,omitempty"`
+}
+
+// NodeSelectorRequirement - Node Label Match Expression
+type NodeSelectorRequirement struct {
+	Key      *string   `json:"key,omitempty"`
+	Operator *string   `json:"operator,omitempty"`
+	Values   *[]string `json:"values,omitempty"`
+}
+
+// PreferredNodeAffinity - Weighted Node Preference
+type PreferredNodeAffinity struct {
+	Weight           *int                       `json:"weight,omitempty"`
+	MatchExpressions *[]NodeSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
 // AutoScaling - Auto Scaling Options
 type AutoScaling struct {
-	Metric           *string `json:"metric,omitempty"`
-	MetricPercentile *string `json:"metricPercentile,omitempty"`
-	Target           *int    `json:"target,omitempty"`
-	MaxScale         *int    `json:"maxScale,omitempty"`
-	MinScale         *int    `json:"minScale,omitempty"`
-	MaxConcurrency   *int    `json:"maxConcurrency,omitempty"`
-	ScaleToZeroDelay *int    `json:"scaleToZeroDelay,omitempty"`
+	Metric           *string          `json:"metric,omitempty"`
+	MetricPercentile *string          `json:"metricPercentile,omitempty"`
+	Target           *int             `json:"target,omitempty"`
+	MaxScale         *int             `json:"maxScale,omitempty"`
+	MinScale         *int             `json:"minScale,omitempty"`
+	MaxConcurrency   *int             `json:"maxConcurrency,omitempty"`
+	ScaleToZeroDelay *int             `json:"scaleToZeroDelay,omitempty"`
+	Multi            *[]MultiMetric   `json:"multi,omitempty"`
+	Keda             *KedaAutoScaling `json:"keda,omitempty"`
+}
+
+// MultiMetric - Scaling metric with its own target
+type MultiMetric struct {
+	Metric *string `json:"metric,omitempty"`
+	Target *int    `json:"target,omitempty"`
+}
+
+// KedaAutoScaling - External (KEDA) Scaling Triggers
+type KedaAutoScaling struct {
+	Triggers        *[]KedaTrigger `json:"triggers,omitempty"`
+	PollingInterval *int           `json:"pollingInterval,omitempty"`
+	CooldownPeriod  *int           `json:"cooldownPeriod,omitempty"`
+}
+
+// KedaTrigger - KEDA Trigger
+type KedaTrigger struct {
+	Type             *string                 `json:"type,omitempty"`
+	Name             *string                 `json:"name,omitempty"`
+	Metadata         *map[string]interface{} `json:"metadata,omitempty"`
+	MetricType       *string                 `json:"metricType,omitempty"`
+	UseCachedMetrics *bool                   `json:"useCachedMetrics,omitempty"`
 }
 
 // FirewallSpec - Firewall Config
@@ -128,13 +192,21 @@ type FirewallSpecInternal struct {
 
 // WorkloadStatus - Workload Status
 type WorkloadStatus struct {
-	ParentID            *string            `json:"parentId,omitempty"`
-	CanonicalEndpoint   *string            `json:"canonicalEndpoint,omitempty"`
-	Endpoint            *string            `json:"endpoint,omitempty"`
-	InternalName        *string            `json:"internalName,omitempty"`
-	CurrentReplicaCount *int               `json:"currentReplicaCount,omitempty"`
-	HealthCheck         *HealthCheckStatus `json:"healthCheck,omitempty"`
-	ResolvedImages      *ResolvedImages    `json:"resolvedImages,omitempty"`
+	ParentID            *string               `json:"parentId,omitempty"`
+	CanonicalEndpoint   *string               `json:"canonicalEndpoint,omitempty"`
+	Endpoint            *string               `json:"endpoint,omitempty"`
+	InternalName        *string               `json:"internalName,omitempty"`
+	CurrentReplicaCount *int                  `json:"currentReplicaCount,omitempty"`
+	HealthCheck         *HealthCheckStatus    `json:"healthCheck,omitempty"`
+	ResolvedImages      *ResolvedImages       `json:"resolvedImages,omitempty"`
+	LoadBalancer        *[]LoadBalancerStatus `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancerStatus - Load Balancer Status
+type LoadBalancerStatus struct {
+	Origin      *string   `json:"origin,omitempty"`
+	Url         *string   `json:"url,omitempty"`
+	IpAddresses *[]string `json:"ipAddresses,omitempty"`
 }
 
 // HealthCheckStatus - Health Check Status
@@ -143,8 +215,8 @@ type HealthCheckStatus struct {
 	Success     *bool   `json:"success,omitempty"`
 	Code        *int    `json:"code,omitempty"`
 	Message     *string `json:"message,omitempty"`
-	Failures    *int    `failures:"parentId,omitempty"`
-	Successes   *int    `successes:"parentId,omitempty"`
+	Failures    *int    `json:"failures,omitempty"`
+	Successes   *int    `json:"successes,omitempty"`
 	LastChecked *string `json:"lastChecked,omitempty"`
 }
 
@@ -181,9 +253,16 @@ type HealthCheckSpec struct {
 
 // VolumeSpec - Volume Spec
 type VolumeSpec struct {
-	Uri            *string `json:"uri,omitempty"`
-	RecoveryPolicy *string `json:"recoveryPolicy,omitempty"`
-	Path           *string `json:"path,omitempty"`
+	Uri            *string       `json:"uri,omitempty"`
+	RecoveryPolicy *string       `json:"recoveryPolicy,omitempty"`
+	Path           *string       `json:"path,omitempty"`
+	Items          *[]VolumeItem `json:"items,omitempty"`
+}
+
+// VolumeItem - Maps a key of a dictionary secret to a file within the volume
+type VolumeItem struct {
+	Key  *string `json:"key,omitempty"`
+	Path *string `json:"path,omitempty"`
 }
 
 // Metrics - Metrics
@@ -234,6 +313,34 @@ type JobSpec struct {
 	ActiveDeadlineSeconds *int    `json:"activeDeadlineSeconds,omitempty"`
 }
 
+// RequestRetryPolicy - Retries of failed requests
+type RequestRetryPolicy struct {
+	Attempts *int      `json:"attempts,omitempty"`
+	RetryOn  *[]string `json:"retryOn,omitempty"`
+}
+
+// WorkloadRoute - Per path prefix traffic settings
+type WorkloadRoute struct {
+	Prefix         *string                     `json:"prefix,omitempty"`
+	TimeoutSeconds *int                        `json:"timeoutSeconds,omitempty"`
+	WorkloadLink   *string                     `json:"workloadLink,omitempty"`
+	Headers        *[]WorkloadRouteHeaderMatch `json:"headers,omitempty"`
+}
+
+// WorkloadRouteHeaderMatch - Header condition a request must meet to use a route
+type WorkloadRouteHeaderMatch struct {
+	Name   *string `json:"name,omitempty"`
+	Exact  *string `json:"exact,omitempty"`
+	Prefix *string `json:"prefix,omitempty"`
+	Regex  *string `json:"regex,omitempty"`
+}
+
+// WorkloadMirror - Copies a percentage of the traffic to a shadow workload
+type WorkloadMirror struct {
+	WorkloadLink *string  `json:"workloadLink,omitempty"`
+	Percent      *float64 `json:"percent,omitempty"`
+}
+
 // Rollout Options
 type RolloutOptions struct {
 	MinReadySeconds        *int    `json:"minReadySeconds,omitempty"`
@@ -252,6 +359,40 @@ type WorkloadSidecar struct {
 	Envoy *any `json:"envoy,omitempty"`
 }
 
+// WorkloadLoadBalancer - Workload Load Balancer
+type WorkloadLoadBalancer struct {
+	Direct      *DirectLoadBalancer `json:"direct,omitempty"`
+	GeoLocation *GeoLocation        `json:"geoLocation,omitempty"`
+}
+
+// DirectLoadBalancer - Direct Load Balancer
+type DirectLoadBalancer struct {
+	Enabled *bool                     `json:"enabled,omitempty"`
+	Ports   *[]DirectLoadBalancerPort `json:"ports,omitempty"`
+}
+
+// DirectLoadBalancerPort - Direct Load Balancer Port
+type DirectLoadBalancerPort struct {
+	ExternalPort  *int    `json:"externalPort,omitempty"`
+	Protocol      *string `json:"protocol,omitempty"`
+	Scheme        *string `json:"scheme,omitempty"`
+	ContainerPort *int    `json:"containerPort,omitempty"`
+}
+
+// GeoLocation - Geo Location Header Injection
+type GeoLocation struct {
+	Enabled *bool               `json:"enabled,omitempty"`
+	Headers *GeoLocationHeaders `json:"headers,omitempty"`
+}
+
+// GeoLocationHeaders - Geo Location Header Names
+type GeoLocationHeaders struct {
+	Asn     *string `json:"asn,omitempty"`
+	City    *string `json:"city,omitempty"`
+	Country *string `json:"country,omitempty"`
+	Region  *string `json:"region,omitempty"`
+}
+
 func (w Workload) RemoveEmptySlices() {
 
 	if w.Spec.Containers != nil {
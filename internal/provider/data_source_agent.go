@@ -0,0 +1,92 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAgent() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: dataSourceAgentRead,
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: NameValidator,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"wait_for_connected": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "5m",
+				ValidateFunc: DurationValidator,
+			},
+			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     agentStatusResource(),
+			},
+		},
+	}
+}
+
+func dataSourceAgentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+
+	agentName := d.Get("name").(string)
+
+	if d.Get("wait_for_connected").(bool) {
+
+		timeout, _ := time.ParseDuration(d.Get("timeout").(string))
+		agent, err := waitForAgentConnected(ctx, c, agentName, timeout)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if agent == nil {
+			return diag.FromErr(fmt.Errorf("agent '%s' did not connect within %s", agentName, timeout))
+		}
+
+		return setAgent(d, agent)
+	}
+
+	agent, _, err := c.GetAgent(agentName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setAgent(d, agent)
+}
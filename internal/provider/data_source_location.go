@@ -2,6 +2,7 @@ package cpln
 
 import (
 	"context"
+	"strconv"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
@@ -80,11 +81,11 @@ func flattenLocationGeo(geo *client.LocationGeo) []interface{} {
 	spec := make(map[string]interface{})
 
 	if geo.Lat != nil {
-		spec["lat"] = *geo.Lat
+		spec["lat"] = float32ToFloat64(*geo.Lat)
 	}
 
 	if geo.Lon != nil {
-		spec["lon"] = *geo.Lon
+		spec["lon"] = float32ToFloat64(*geo.Lon)
 	}
 
 	if geo.Country != nil {
@@ -108,6 +109,14 @@ func flattenLocationGeo(geo *client.LocationGeo) []interface{} {
 	}
 }
 
+// float32ToFloat64 - Converts using the shortest decimal representation to avoid precision drift (i.e. 37.7749)
+func float32ToFloat64(value float32) float64 {
+
+	output, _ := strconv.ParseFloat(strconv.FormatFloat(float64(value), 'f', -1, 32), 64)
+
+	return output
+}
+
 func flattenIpRanges(ipRanges *[]string) []interface{} {
 
 	if len(*ipRanges) > 0 {
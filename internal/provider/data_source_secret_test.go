@@ -0,0 +1,70 @@
+package cpln
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCplnSecret_basic(t *testing.T) {
+
+	random := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t, "DATA_SOURCE_SECRET") },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckControlPlaneSecretCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCplnSecretConfig(random),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cpln_secret.metadata", "type", "userpass"),
+					resource.TestCheckResourceAttr("data.cpln_secret.metadata", "userpass.#", "0"),
+					resource.TestCheckResourceAttr("data.cpln_secret.revealed", "type", "userpass"),
+					resource.TestCheckResourceAttr("data.cpln_secret.revealed", "userpass.0.username", "cpln_username"),
+					resource.TestCheckResourceAttr("data.cpln_secret.revealed", "userpass.0.password", "cpln_password"),
+					resource.TestCheckResourceAttr("data.cpln_secret.dictionary", "dictionary_as_envs.key01", fmt.Sprintf("cpln://secret/dictionary-%s.key01", random)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceCplnSecretConfig(random string) string {
+
+	return fmt.Sprintf(`
+
+	resource "cpln_secret" "userpass" {
+		name = "userpass-%s"
+
+		userpass {
+			username = "cpln_username"
+			password = "cpln_password"
+		}
+	}
+
+	resource "cpln_secret" "dictionary" {
+		name = "dictionary-%s"
+
+		dictionary = {
+			key01 = "value01"
+		}
+	}
+
+	data "cpln_secret" "metadata" {
+		name = cpln_secret.userpass.name
+	}
+
+	data "cpln_secret" "revealed" {
+		name   = cpln_secret.userpass.name
+		reveal = true
+	}
+
+	data "cpln_secret" "dictionary" {
+		name   = cpln_secret.dictionary.name
+		reveal = true
+	}
+	`, random, random)
+}
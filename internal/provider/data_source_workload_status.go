@@ -0,0 +1,152 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceWorkloadStatus() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: dataSourceWorkloadStatusRead,
+		Schema: map[string]*schema.Schema{
+			"gvc": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: NameValidator,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: NameValidator,
+			},
+			"wait_until_healthy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "5m",
+				ValidateFunc: DurationValidator,
+			},
+			"healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"current_replica_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"canonical_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     WorkloadStatusResource(),
+			},
+		},
+	}
+}
+
+func dataSourceWorkloadStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+
+	gvcName := d.Get("gvc").(string)
+	workloadName := d.Get("name").(string)
+
+	workload, _, err := c.GetWorkload(workloadName, gvcName)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("wait_until_healthy").(bool) {
+
+		timeout, _ := time.ParseDuration(d.Get("timeout").(string))
+		deadline := time.Now().Add(timeout)
+
+		for !isWorkloadHealthy(workload.Status) {
+
+			if time.Now().After(deadline) {
+				return diag.FromErr(fmt.Errorf("workload '%s' in GVC '%s' did not report healthy within %s", workloadName, gvcName, timeout))
+			}
+
+			select {
+			case <-ctx.Done():
+				return diag.FromErr(ctx.Err())
+			case <-time.After(15 * time.Second):
+			}
+
+			workload, _, err = c.GetWorkload(workloadName, gvcName)
+
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return setWorkloadStatus(d, workload, gvcName)
+}
+
+func setWorkloadStatus(d *schema.ResourceData, workload *client.Workload, gvcName string) diag.Diagnostics {
+
+	if workload == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", gvcName, *workload.Name))
+
+	if err := d.Set("healthy", isWorkloadHealthy(workload.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", flattenWorkloadStatus(workload.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if workload.Status == nil {
+		return nil
+	}
+
+	if err := d.Set("current_replica_count", workload.Status.CurrentReplicaCount); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("endpoint", workload.Status.Endpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("canonical_endpoint", workload.Status.CanonicalEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// isWorkloadHealthy - A workload is considered healthy once its health check is active and succeeding
+func isWorkloadHealthy(status *client.WorkloadStatus) bool {
+
+	if status == nil || status.HealthCheck == nil {
+		return false
+	}
+
+	healthCheck := status.HealthCheck
+
+	return healthCheck.Active != nil && *healthCheck.Active && healthCheck.Success != nil && *healthCheck.Success
+}
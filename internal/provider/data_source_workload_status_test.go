@@ -0,0 +1,35 @@
+package cpln
+
+import (
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+)
+
+/*** Unit Tests ***/
+func TestControlPlane_IsWorkloadHealthy(t *testing.T) {
+
+	active := true
+	inactive := false
+	success := true
+	failure := false
+
+	cases := []struct {
+		name     string
+		status   *client.WorkloadStatus
+		expected bool
+	}{
+		{"nil status", nil, false},
+		{"no health check", &client.WorkloadStatus{}, false},
+		{"inactive", &client.WorkloadStatus{HealthCheck: &client.HealthCheckStatus{Active: &inactive, Success: &success}}, false},
+		{"failing", &client.WorkloadStatus{HealthCheck: &client.HealthCheckStatus{Active: &active, Success: &failure}}, false},
+		{"no success yet", &client.WorkloadStatus{HealthCheck: &client.HealthCheckStatus{Active: &active}}, false},
+		{"healthy", &client.WorkloadStatus{HealthCheck: &client.HealthCheckStatus{Active: &active, Success: &success}}, true},
+	}
+
+	for _, c := range cases {
+		if healthy := isWorkloadHealthy(c.status); healthy != c.expected {
+			t.Errorf("Workload health for case '%s' was not evaluated correctly. Expected: %t, Got: %t", c.name, c.expected, healthy)
+		}
+	}
+}
@@ -1,11 +1,17 @@
 package cpln
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"path"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
@@ -149,6 +155,11 @@ func DiffSuppressDescription(k, old, new string, d *schema.ResourceData) bool {
 	return old == new
 }
 
+// DiffSuppressLinkName - Treats a name and a full link to the same resource as equal
+func DiffSuppressLinkName(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && GetNameFromSelfLink(old) == GetNameFromSelfLink(new)
+}
+
 func GetInt(s interface{}) *int {
 	if s == nil {
 		return nil
@@ -330,6 +341,7 @@ func KindValidator(val interface{}, key string) (warns []string, errs []error) {
 		"accessreport",
 		"policymembership",
 		"auditctx",
+		"ipset",
 	}
 
 	for _, v := range kinds {
@@ -393,6 +405,119 @@ func AwsRoleArnValidator(val interface{}, key string) (warns []string, errs []er
 	return
 }
 
+func AwsPolicyDocumentValidator(val interface{}, key string) (warns []string, errs []error) {
+
+	v := val.(string)
+
+	document, err := ParseAwsPolicyDocument(v)
+
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a valid AWS policy document, got error: %s", key, err))
+		return
+	}
+
+	if document.Statement == nil || len(*document.Statement) == 0 {
+		errs = append(errs, fmt.Errorf("%q must contain at least one statement", key))
+		return
+	}
+
+	for index, statement := range *document.Statement {
+		if err := validateAwsPolicyStatement(statement); err != nil {
+			errs = append(errs, fmt.Errorf("%q statement %d is invalid: %s", key, index, err))
+		}
+	}
+
+	return
+}
+
+// validateAwsPolicyStatement - An element and its Not counterpart cannot be used in the same statement
+func validateAwsPolicyStatement(statement client.AwsPolicyStatement) error {
+
+	if statement.Principal != nil && statement.NotPrincipal != nil {
+		return fmt.Errorf("Principal and NotPrincipal cannot both be set")
+	}
+
+	if statement.Action != nil && statement.NotAction != nil {
+		return fmt.Errorf("Action and NotAction cannot both be set")
+	}
+
+	if statement.Resource != nil && statement.NotResource != nil {
+		return fmt.Errorf("Resource and NotResource cannot both be set")
+	}
+
+	return nil
+}
+
+// ParseAwsPolicyDocument - Parse a JSON AWS policy document into its normalized form
+func ParseAwsPolicyDocument(document string) (*client.AwsPolicyDocument, error) {
+
+	output := client.AwsPolicyDocument{}
+
+	// A single statement may be given as an object rather than a list
+	raw := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal([]byte(document), &raw); err == nil {
+
+		if statement, ok := raw["Statement"]; ok && bytes.HasPrefix(bytes.TrimSpace(statement), []byte("{")) {
+
+			raw["Statement"] = append(append([]byte("["), statement...), ']')
+
+			if normalized, err := json.Marshal(raw); err == nil {
+				document = string(normalized)
+			}
+		}
+	}
+
+	// Reject the elements that are not modeled rather than silently dropping them
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the policy document")
+	}
+
+	NormalizeAwsPolicyDocument(&output)
+
+	return &output, nil
+}
+
+// NormalizeAwsPolicyDocument - Sort the unordered lists of a policy document so equivalent documents compare equal
+func NormalizeAwsPolicyDocument(document *client.AwsPolicyDocument) {
+
+	if document == nil || document.Statement == nil {
+		return
+	}
+
+	for _, statement := range *document.Statement {
+
+		for _, list := range []*client.AwsPolicyStringList{statement.Action, statement.NotAction, statement.Resource, statement.NotResource} {
+			if list != nil {
+				sort.Strings(*list)
+			}
+		}
+
+		for _, principal := range []*client.AwsPolicyPrincipal{statement.Principal, statement.NotPrincipal} {
+			if principal != nil {
+				for _, identifiers := range *principal {
+					sort.Strings(identifiers)
+				}
+			}
+		}
+
+		if statement.Condition != nil {
+			for _, variables := range *statement.Condition {
+				for _, values := range variables {
+					sort.Strings(values)
+				}
+			}
+		}
+	}
+}
+
 func PortValidator(val interface{}, key string) (warns []string, errs []error) {
 
 	v := val.(int)
@@ -404,6 +529,25 @@ func PortValidator(val interface{}, key string) (warns []string, errs []error) {
 	return
 }
 
+func VolumePathValidator(val interface{}, key string) (warns []string, errs []error) {
+
+	v := val.(string)
+
+	if !path.IsAbs(v) {
+		errs = append(errs, fmt.Errorf("%q must be an absolute path, got: %s", key, v))
+		return
+	}
+
+	v = path.Clean(v)
+	v = strings.TrimRight(v, "/")
+
+	if v == "/dev/log" || v == "/dev" || v == "/tmp" || v == "/var" || v == "/var/log" {
+		errs = append(errs, fmt.Errorf("%q is set to a reserved path, got: %s", key, v))
+	}
+
+	return
+}
+
 func ObservabilityValidator(val interface{}, key string) (warns []string, errs []error) {
 
 	v := val.(int)
@@ -419,13 +563,18 @@ func CpuMemoryValidator(val interface{}, key string) (warns []string, errs []err
 
 	v := val.(string)
 
-	re := regexp.MustCompile(`^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`)
+	quantity, err := ParseQuantity(v)
 
-	if !re.MatchString(v) {
+	if err != nil {
 		errs = append(errs, fmt.Errorf("%q is invalid, got: %s", key, v))
 		return
 	}
 
+	if quantity < 0 {
+		errs = append(errs, fmt.Errorf("%q cannot be negative, got: %s", key, v))
+		return
+	}
+
 	if len(v) > 20 {
 		errs = append(errs, fmt.Errorf("%q cannot be greater than 20 characters, got: %d", key, len(v)))
 	}
@@ -433,6 +582,48 @@ func CpuMemoryValidator(val interface{}, key string) (warns []string, errs []err
 	return
 }
 
+// ContainerNameValidator - Validate a workload container or init container name against the names reserved by the platform
+func ContainerNameValidator(val interface{}, key string) (warns []string, errs []error) {
+
+	warns, errs = NameValidator(val, key)
+
+	v := val.(string)
+
+	if strings.HasPrefix(v, "cpln-") {
+		errs = append(errs, fmt.Errorf("%q cannot start with 'cpln-', got: %s", key, v))
+		return
+	}
+
+	if v == "istio-proxy" || v == "queue-proxy" || v == "istio-validation" || v == "cpln-envoy-assassin" || v == "cpln-writer-proxy" || v == "cpln-reader-proxy" || v == "cpln-dbaas-config" {
+		errs = append(errs, fmt.Errorf("%q cannot be set to 'istio-proxy', 'queue-proxy', 'istio-validation', 'cpln-envoy-assassin', 'cpln-writer-proxy', 'cpln-reader-proxy', 'cpln-dbaas-config', got: %s", key, v))
+	}
+
+	return
+}
+
+// ContainerEnvValidator - Validate the environment variables of a workload container or init container
+func ContainerEnvValidator(val interface{}, key string) (warns []string, errs []error) {
+
+	v := val.(map[string]interface{})
+
+	for name, value := range v {
+
+		nameLower := strings.ToLower(name)
+
+		if nameLower == "k_service" || nameLower == "k_configuration" || nameLower == "k_revision" {
+			errs = append(errs, fmt.Errorf("%q cannot be 'K_SERVICE', 'K_CONFIGURATION', 'K_REVISION', got: %s", key, nameLower))
+		}
+
+		maxValueLength := 4 * 1024
+
+		if len(value.(string)) > maxValueLength {
+			errs = append(errs, fmt.Errorf("%q length cannot be > %d, got: %d", key, maxValueLength, len(value.(string))))
+		}
+	}
+
+	return
+}
+
 func ThresholdValidator(val interface{}, key string) (warns []string, errs []error) {
 	v := val.(int)
 	if v < 1 || v > 20 {
@@ -442,6 +633,24 @@ func ThresholdValidator(val interface{}, key string) (warns []string, errs []err
 	return
 }
 
+func DurationValidator(val interface{}, key string) (warns []string, errs []error) {
+
+	v := val.(string)
+
+	duration, err := time.ParseDuration(v)
+
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a valid duration (i.e. 30s, 5m, 1h), got: %s", key, v))
+		return
+	}
+
+	if duration <= 0 {
+		errs = append(errs, fmt.Errorf("%q must be greater than 0, got: %s", key, v))
+	}
+
+	return
+}
+
 func QuerySchemaResource() *schema.Resource {
 
 	return &schema.Resource{
@@ -762,13 +971,52 @@ func GetNameFromSelfLink(selfLink string) string {
 	return parts[len(parts)-1]
 }
 
-func ExtractNumberAndCharactersFromString(value string) (int, string) {
+var quantityRegex = regexp.MustCompile(`^([+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+))(?:([eE][+-]?[0-9]+)|(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E))?$`)
+
+var quantitySuffixes = map[string]float64{
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+	"Pi": 1 << 50,
+	"Ei": 1 << 60,
+	"n":  1e-9,
+	"u":  1e-6,
+	"m":  1e-3,
+	"":   1,
+	"k":  1e3,
+	"M":  1e6,
+	"G":  1e9,
+	"T":  1e12,
+	"P":  1e15,
+	"E":  1e18,
+}
+
+// ParseQuantity - Parse a Kubernetes quantity (e.g. 500m, 0.5, 1.5Gi, 1e3) into its value in base units (cores or bytes)
+func ParseQuantity(value string) (float64, error) {
+
+	matches := quantityRegex.FindStringSubmatch(strings.TrimSpace(value))
 
-	numberRegex := regexp.MustCompile("[0-9]+")
-	charactersRegex := regexp.MustCompile("[A-Za-z]+")
+	if matches == nil {
+		return 0, fmt.Errorf("invalid quantity: %q", value)
+	}
+
+	number, err := strconv.ParseFloat(matches[1], 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity: %q", value)
+	}
 
-	number, _ := strconv.Atoi(numberRegex.FindString(value))
-	characters := charactersRegex.FindString(value)
+	// Decimal exponent form, e.g. 1e3
+	if matches[2] != "" {
+		exponent, err := strconv.Atoi(matches[2][1:])
+
+		if err != nil {
+			return 0, fmt.Errorf("invalid quantity: %q", value)
+		}
+
+		return number * math.Pow10(exponent), nil
+	}
 
-	return number, characters
+	return number * quantitySuffixes[matches[3]], nil
 }
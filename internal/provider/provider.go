@@ -43,32 +43,44 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"cpln_agent":               resourceAgent(),
-			"cpln_audit_context":       resourceAuditContext(),
-			"cpln_cloud_account":       resourceCloudAccount(),
-			"cpln_domain":              resourceDomain(),
-			"cpln_domain_route":        resourceDomainRoute(),
-			"cpln_group":               resourceGroup(),
-			"cpln_gvc":                 resourceGvc(),
-			"cpln_identity":            resourceIdentity(),
-			"cpln_org_logging":         resourceOrgLogging(),
-			"cpln_org_tracing":         resourceOrgTracing(),
-			"cpln_org":                 resourceOrg(),
-			"cpln_policy":              resourcePolicy(),
-			"cpln_secret":              resourceSecret(),
-			"cpln_service_account":     resourceServiceAccount(),
-			"cpln_service_account_key": resourceServiceAccountKey(),
-			"cpln_volume_set":          resourceVolumeSet(),
-			"cpln_workload":            resourceWorkload(),
+			"cpln_agent":                            resourceAgent(),
+			"cpln_audit_context":                    resourceAuditContext(),
+			"cpln_cloud_account":                    resourceCloudAccount(),
+			"cpln_custom_location":                  resourceCustomLocation(),
+			"cpln_domain":                           resourceDomain(),
+			"cpln_domain_route":                     resourceDomainRoute(),
+			"cpln_group":                            resourceGroup(),
+			"cpln_gvc":                              resourceGvc(),
+			"cpln_identity":                         resourceIdentity(),
+			"cpln_identity_network_resource":        resourceIdentityNetworkResource(),
+			"cpln_identity_native_network_resource": resourceIdentityNativeNetworkResource(),
+			"cpln_ipset":                            resourceIpSet(),
+			"cpln_location":                         resourceLocation(),
+			"cpln_mk8s":                             resourceMk8s(),
+			"cpln_org_logging":                      resourceOrgLogging(),
+			"cpln_org_tracing":                      resourceOrgTracing(),
+			"cpln_org":                              resourceOrg(),
+			"cpln_policy":                           resourcePolicy(),
+			"cpln_quota":                            resourceQuota(),
+			"cpln_secret":                           resourceSecret(),
+			"cpln_service_account":                  resourceServiceAccount(),
+			"cpln_service_account_key":              resourceServiceAccountKey(),
+			"cpln_volume_set":                       resourceVolumeSet(),
+			"cpln_volume_set_restore":               resourceVolumeSetRestore(),
+			"cpln_volume_set_snapshot":              resourceVolumeSetSnapshot(),
+			"cpln_workload":                         resourceWorkload(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			// "cpln_gvcs": dataSourceGvcs(),
-			"cpln_cloud_account": dataSourceCloudAccount(),
-			"cpln_gvc":           dataSourceGvc(),
-			"cpln_location":      dataSourceLocation(),
-			"cpln_locations":     dataSourceLocations(),
-			"cpln_org":           dataSourceOrg(),
+			"cpln_agent":           dataSourceAgent(),
+			"cpln_cloud_account":   dataSourceCloudAccount(),
+			"cpln_gvc":             dataSourceGvc(),
+			"cpln_location":        dataSourceLocation(),
+			"cpln_locations":       dataSourceLocations(),
+			"cpln_org":             dataSourceOrg(),
+			"cpln_secret":          dataSourceSecret(),
+			"cpln_workload_status": dataSourceWorkloadStatus(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
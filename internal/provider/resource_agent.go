@@ -2,6 +2,10 @@ package cpln
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
@@ -18,6 +22,7 @@ func resourceAgent() *schema.Resource {
 		ReadContext:   resourceAgentRead,
 		UpdateContext: resourceAgentUpdate,
 		DeleteContext: resourceAgentDelete,
+		CustomizeDiff: customizeDiffAgent,
 		Schema: map[string]*schema.Schema{
 			"cpln_id": {
 				Type:     schema.TypeString,
@@ -52,11 +57,83 @@ func resourceAgent() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"agent_image": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ecs_user_data_secret_arn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"kubernetes_manifest": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"docker_compose": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"cloud_init": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"ecs_task_definition": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     agentStatusResource(),
+			},
 		},
 		Importer: &schema.ResourceImporter{},
 	}
 }
 
+func agentStatusResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connected": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"last_heartbeat": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"active_network_resources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"identity_link": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func resourceAgentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// log.Printf("[INFO] Method: resourceAgentCreate")
@@ -87,7 +164,16 @@ func resourceAgentCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
-	return setAgent(d, newAgent)
+	// The agent is tracked before rendering, so a user data that cannot be rendered does not leave it behind
+	if diags := setAgent(d, newAgent); diags.HasError() {
+		return diags
+	}
+
+	if err := setAgentArtifacts(d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
 }
 
 func resourceAgentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -126,6 +212,10 @@ func setAgent(d *schema.ResourceData, agent *client.Agent) diag.Diagnostics {
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("status", flattenAgentStatus(agent.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -133,6 +223,13 @@ func resourceAgentUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// log.Printf("[INFO] Method: resourceAgentUpdate")
 
+	// The artifacts are rendered from the user data kept in the state, since the registration token is only returned on create
+	if d.HasChanges("agent_image", "ecs_user_data_secret_arn") {
+		if err := setAgentArtifacts(d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChanges("description", "tags") {
 
 		agentToUpdate := client.Agent{}
@@ -172,3 +269,298 @@ func resourceAgentDelete(ctx context.Context, d *schema.ResourceData, m interfac
 
 	return nil
 }
+
+// customizeDiffAgent - The artifacts are computed from the arguments they are rendered with, so they are marked as unknown when those change
+func customizeDiffAgent(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if diff.HasChange("agent_image") {
+		for _, key := range []string{"kubernetes_manifest", "docker_compose", "cloud_init", "ecs_task_definition"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if diff.HasChange("ecs_user_data_secret_arn") {
+		return diff.SetNewComputed("ecs_task_definition")
+	}
+
+	return nil
+}
+
+/*** Flatten ***/
+func flattenAgentStatus(status *client.AgentStatus) []interface{} {
+
+	if status == nil {
+		return nil
+	}
+
+	output := map[string]interface{}{
+		"connected": isAgentConnected(status),
+	}
+
+	if status.LastHeartbeat != nil {
+		output["last_heartbeat"] = *status.LastHeartbeat
+	}
+
+	if status.Version != nil {
+		output["version"] = *status.Version
+	}
+
+	if status.PeerCount != nil {
+		output["peer_count"] = *status.PeerCount
+	}
+
+	if status.NetworkResources != nil {
+
+		networkResources := []interface{}{}
+
+		for _, networkResource := range *status.NetworkResources {
+
+			item := map[string]interface{}{}
+
+			if networkResource.IdentityLink != nil {
+				item["identity_link"] = *networkResource.IdentityLink
+			}
+
+			if networkResource.Name != nil {
+				item["name"] = *networkResource.Name
+			}
+
+			networkResources = append(networkResources, item)
+		}
+
+		output["active_network_resources"] = networkResources
+	}
+
+	return []interface{}{
+		output,
+	}
+}
+
+/*** Render ***/
+
+// agentUserDataPath - Path at which the rendered artifacts mount the user data inside the agent container
+const agentUserDataPath = "/etc/cpln/agent/user-data.json"
+
+// agentUserDataEnvironmentVariable - Environment variable through which the ECS task definition passes the user data, since ECS cannot mount a secret as a file
+const agentUserDataEnvironmentVariable = "USER_DATA"
+
+// setAgentArtifacts - Renders the deployment artifacts of the agent from its user data. Nothing is rendered without an agent image,
+// or when the user data is not known (i.e. after an import)
+func setAgentArtifacts(d *schema.ResourceData) error {
+
+	artifacts := map[string]string{
+		"kubernetes_manifest": "",
+		"docker_compose":      "",
+		"cloud_init":          "",
+		"ecs_task_definition": "",
+	}
+
+	name := d.Get("name").(string)
+	image := d.Get("agent_image").(string)
+
+	if userData := d.Get("user_data").(string); userData != "" && image != "" {
+
+		// The agent registers with the hub using the token, the artifacts are useless without either
+		bootstrapConfig := client.AgentBootstrapConfig{}
+
+		if err := json.Unmarshal([]byte(userData), &bootstrapConfig); err != nil {
+			return fmt.Errorf("unable to parse the user data of agent '%s'", name)
+		}
+
+		if bootstrapConfig.RegistrationToken == nil || *bootstrapConfig.RegistrationToken == "" || bootstrapConfig.HubEndpoint == nil || *bootstrapConfig.HubEndpoint == "" {
+			return fmt.Errorf("the user data of agent '%s' is missing its registration token or hub endpoint", name)
+		}
+
+		artifacts["kubernetes_manifest"] = renderAgentKubernetesManifest(name, image, userData)
+		artifacts["docker_compose"] = renderAgentDockerCompose(name, image, userData)
+		artifacts["cloud_init"] = renderAgentCloudInit(name, image, userData)
+
+		// The task definition can only reference the user data, which has to be stored in AWS Secrets Manager first
+		if secretArn := d.Get("ecs_user_data_secret_arn").(string); secretArn != "" {
+
+			ecsTaskDefinition, err := renderAgentEcsTaskDefinition(name, image, secretArn)
+
+			if err != nil {
+				return err
+			}
+
+			artifacts["ecs_task_definition"] = ecsTaskDefinition
+		}
+	}
+
+	for key, value := range artifacts {
+		if err := d.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderAgentKubernetesManifest - A Secret holding the user data and a Deployment running a single agent replica with the Secret mounted as a file
+func renderAgentKubernetesManifest(name string, image string, userData string) string {
+
+	resourceName := "cpln-agent-" + name
+	directory, file := path.Split(agentUserDataPath)
+
+	var builder strings.Builder
+
+	builder.WriteString("apiVersion: v1\n")
+	builder.WriteString("kind: Secret\n")
+	builder.WriteString("metadata:\n")
+	builder.WriteString(fmt.Sprintf("  name: %s\n", quoteYaml(resourceName)))
+	builder.WriteString("type: Opaque\n")
+	builder.WriteString("stringData:\n")
+	builder.WriteString(fmt.Sprintf("  %s: %s\n", quoteYaml(file), quoteYaml(userData)))
+	builder.WriteString("---\n")
+	builder.WriteString("apiVersion: apps/v1\n")
+	builder.WriteString("kind: Deployment\n")
+	builder.WriteString("metadata:\n")
+	builder.WriteString(fmt.Sprintf("  name: %s\n", quoteYaml(resourceName)))
+	builder.WriteString("spec:\n")
+	builder.WriteString("  replicas: 1\n")
+	builder.WriteString("  selector:\n")
+	builder.WriteString("    matchLabels:\n")
+	builder.WriteString(fmt.Sprintf("      app: %s\n", quoteYaml(resourceName)))
+	builder.WriteString("  template:\n")
+	builder.WriteString("    metadata:\n")
+	builder.WriteString("      labels:\n")
+	builder.WriteString(fmt.Sprintf("        app: %s\n", quoteYaml(resourceName)))
+	builder.WriteString("    spec:\n")
+	builder.WriteString("      containers:\n")
+	builder.WriteString("        - name: agent\n")
+	builder.WriteString(fmt.Sprintf("          image: %s\n", quoteYaml(image)))
+	builder.WriteString("          volumeMounts:\n")
+	builder.WriteString("            - name: user-data\n")
+	builder.WriteString(fmt.Sprintf("              mountPath: %s\n", quoteYaml(strings.TrimSuffix(directory, "/"))))
+	builder.WriteString("              readOnly: true\n")
+	builder.WriteString("      volumes:\n")
+	builder.WriteString("        - name: user-data\n")
+	builder.WriteString("          secret:\n")
+	builder.WriteString(fmt.Sprintf("            secretName: %s\n", quoteYaml(resourceName)))
+
+	return builder.String()
+}
+
+// renderAgentDockerCompose - A Docker Compose file with a single agent service and the user data mounted as a read-only config file
+func renderAgentDockerCompose(name string, image string, userData string) string {
+
+	var builder strings.Builder
+
+	builder.WriteString("services:\n")
+	builder.WriteString(fmt.Sprintf("  %s:\n", quoteYaml("cpln-agent-"+name)))
+	builder.WriteString(fmt.Sprintf("    image: %s\n", quoteYaml(image)))
+	builder.WriteString("    restart: always\n")
+	builder.WriteString("    configs:\n")
+	builder.WriteString("      - source: user-data\n")
+	builder.WriteString(fmt.Sprintf("        target: %s\n", quoteYaml(agentUserDataPath)))
+	builder.WriteString("        mode: 0400\n")
+	builder.WriteString("configs:\n")
+	builder.WriteString("  user-data:\n")
+
+	// Compose interpolates variables in the whole file, so dollar signs are escaped
+	builder.WriteString(fmt.Sprintf("    content: %s\n", quoteYaml(strings.ReplaceAll(userData, "$", "$$"))))
+
+	return builder.String()
+}
+
+// renderAgentCloudInit - A cloud-config that installs Docker, writes the user data to a root-only file and runs the agent with that file mounted
+func renderAgentCloudInit(name string, image string, userData string) string {
+
+	var builder strings.Builder
+
+	builder.WriteString("#cloud-config\n")
+	builder.WriteString("packages:\n")
+	builder.WriteString("  - docker.io\n")
+	builder.WriteString("write_files:\n")
+	builder.WriteString(fmt.Sprintf("  - path: %s\n", quoteYaml(agentUserDataPath)))
+	builder.WriteString("    owner: root:root\n")
+	builder.WriteString("    permissions: \"0600\"\n")
+	builder.WriteString(fmt.Sprintf("    content: %s\n", quoteYaml(userData)))
+	builder.WriteString("runcmd:\n")
+	builder.WriteString("  - systemctl enable --now docker\n")
+	builder.WriteString(fmt.Sprintf("  - %s\n", quoteYaml(fmt.Sprintf("docker run -d --restart always --name cpln-agent-%s -v %s:%s:ro %s", name, agentUserDataPath, agentUserDataPath, image))))
+
+	return builder.String()
+}
+
+// renderAgentEcsTaskDefinition - A Fargate task definition running the agent as its only container, with the user data taken from AWS Secrets Manager
+func renderAgentEcsTaskDefinition(name string, image string, secretArn string) (string, error) {
+
+	taskDefinition := map[string]interface{}{
+		"family":                  "cpln-agent-" + name,
+		"networkMode":             "awsvpc",
+		"requiresCompatibilities": []string{"FARGATE"},
+		"cpu":                     "256",
+		"memory":                  "512",
+		"containerDefinitions": []map[string]interface{}{
+			{
+				"name":      "agent",
+				"image":     image,
+				"essential": true,
+				"secrets": []map[string]string{
+					{
+						"name":      agentUserDataEnvironmentVariable,
+						"valueFrom": secretArn,
+					},
+				},
+			},
+		},
+	}
+
+	output, err := json.MarshalIndent(taskDefinition, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+// quoteYaml - Double-quoted JSON strings are valid YAML scalars, which avoids escaping issues with tokens and endpoints
+func quoteYaml(value string) string {
+
+	quoted, _ := json.Marshal(value)
+
+	return string(quoted)
+}
+
+/*** Helpers ***/
+func isAgentConnected(status *client.AgentStatus) bool {
+	return status != nil && status.Connected != nil && *status.Connected
+}
+
+// waitForAgentConnected - Polls the agent until it reports as connected. Returns nil when the timeout elapses first
+func waitForAgentConnected(ctx context.Context, c *client.Client, agentName string, timeout time.Duration) (*client.Agent, error) {
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+
+		agent, _, err := c.GetAgent(agentName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if isAgentConnected(agent.Status) {
+			return agent, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(15 * time.Second):
+		}
+	}
+}
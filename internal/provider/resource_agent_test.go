@@ -1,13 +1,18 @@
 package cpln
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
+	"github.com/go-test/deep"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -75,6 +80,7 @@ func testAccControlPlaneAgent(agentName, agentDescription string) string {
 	resource "cpln_agent" "new" {
 		name        = "%s"	
 		description = "%s"
+		agent_image = "registry.example.com/cpln-agent:1.4.0"
   
 		tags = {
 		  terraform_generated = "true"
@@ -115,3 +121,279 @@ func testAccCheckControlPlaneAgentCheckDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+/*** Unit Tests ***/
+func TestControlPlane_FlattenAgentStatus(t *testing.T) {
+
+	status, expectedFlatten := generateTestAgentStatus()
+	flattenedStatus := flattenAgentStatus(status)
+
+	if diff := deep.Equal(flattenedStatus, expectedFlatten); diff != nil {
+		t.Errorf("Agent status was not flattened correctly. Diff: %s", diff)
+	}
+
+	if flattenAgentStatus(nil) != nil {
+		t.Errorf("A missing agent status should be flattened to nil")
+	}
+}
+
+func TestControlPlane_IsAgentConnected(t *testing.T) {
+
+	cases := []struct {
+		status   *client.AgentStatus
+		expected bool
+	}{
+		{nil, false},
+		{&client.AgentStatus{}, false},
+		{&client.AgentStatus{Connected: GetBool(false)}, false},
+		{&client.AgentStatus{Connected: GetBool(true)}, true},
+	}
+
+	for index, testCase := range cases {
+		if isAgentConnected(testCase.status) != testCase.expected {
+			t.Errorf("Case %d: expected connected to be %t", index, testCase.expected)
+		}
+	}
+}
+
+func TestControlPlane_RenderAgentDockerCompose(t *testing.T) {
+
+	compose := renderAgentDockerCompose("agent-example", "registry.example.com/agent:1.4.0", `{"registrationToken":"token-$abc"}`)
+	expectedCompose := `services:
+  "cpln-agent-agent-example":
+    image: "registry.example.com/agent:1.4.0"
+    restart: always
+    configs:
+      - source: user-data
+        target: "/etc/cpln/agent/user-data.json"
+        mode: 0400
+configs:
+  user-data:
+    content: "{\"registrationToken\":\"token-$$abc\"}"
+`
+
+	if diff := deep.Equal(compose, expectedCompose); diff != nil {
+		t.Errorf("Docker Compose was not rendered correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_RenderAgentKubernetesManifest(t *testing.T) {
+
+	userData := generateTestAgentUserData()
+	manifest := renderAgentKubernetesManifest("agent-example", "registry.example.com/agent:1.4.0", userData)
+	documents := strings.Split(manifest, "---\n")
+
+	if len(documents) != 2 {
+		t.Fatalf("Expected a Secret and a Deployment, got %d documents", len(documents))
+	}
+
+	for _, expected := range []string{"kind: Secret\n", "stringData:\n", fmt.Sprintf(`  "user-data.json": %s`, quoteYaml(userData))} {
+		if !strings.Contains(documents[0], expected) {
+			t.Errorf("Secret is missing %q", expected)
+		}
+	}
+
+	for _, expected := range []string{
+		"kind: Deployment\n",
+		`          image: "registry.example.com/agent:1.4.0"`,
+		`              mountPath: "/etc/cpln/agent"`,
+		`            secretName: "cpln-agent-agent-example"`,
+	} {
+		if !strings.Contains(documents[1], expected) {
+			t.Errorf("Deployment is missing %q", expected)
+		}
+	}
+
+	if strings.Contains(documents[1], "token-") {
+		t.Errorf("The registration token should only be part of the Secret")
+	}
+}
+
+func TestControlPlane_RenderAgentCloudInit(t *testing.T) {
+
+	userData := generateTestAgentUserData()
+	cloudInit := renderAgentCloudInit("agent-example", "registry.example.com/agent:1.4.0", userData)
+
+	for _, expected := range []string{
+		"#cloud-config\n",
+		fmt.Sprintf("    content: %s\n", quoteYaml(userData)),
+		`  - "docker run -d --restart always --name cpln-agent-agent-example -v /etc/cpln/agent/user-data.json:/etc/cpln/agent/user-data.json:ro registry.example.com/agent:1.4.0"`,
+	} {
+		if !strings.Contains(cloudInit, expected) {
+			t.Errorf("Cloud-init is missing %q", expected)
+		}
+	}
+}
+
+func TestControlPlane_RenderAgentEcsTaskDefinition(t *testing.T) {
+
+	secretArn := "arn:aws:secretsmanager:us-east-1:123456789012:secret:cpln-agent-user-data"
+	rendered, err := renderAgentEcsTaskDefinition("agent-example", "registry.example.com/agent:1.4.0", secretArn)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var taskDefinition struct {
+		Family               string `json:"family"`
+		ContainerDefinitions []struct {
+			Image       string              `json:"image"`
+			Environment []map[string]string `json:"environment"`
+			Secrets     []map[string]string `json:"secrets"`
+		} `json:"containerDefinitions"`
+	}
+
+	if err := json.Unmarshal([]byte(rendered), &taskDefinition); err != nil {
+		t.Fatalf("ECS task definition is not valid JSON: %s", err)
+	}
+
+	if taskDefinition.Family != "cpln-agent-agent-example" || len(taskDefinition.ContainerDefinitions) != 1 {
+		t.Fatalf("Unexpected ECS task definition: %s", rendered)
+	}
+
+	expectedSecrets := []map[string]string{
+		{"name": "USER_DATA", "valueFrom": secretArn},
+	}
+
+	if diff := deep.Equal(taskDefinition.ContainerDefinitions[0].Secrets, expectedSecrets); diff != nil {
+		t.Errorf("ECS secrets were not rendered correctly. Diff: %s", diff)
+	}
+
+	if taskDefinition.ContainerDefinitions[0].Environment != nil {
+		t.Errorf("The user data should not be passed as plain environment variables")
+	}
+}
+
+func TestControlPlane_SetAgentArtifacts(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceAgent().Schema, map[string]interface{}{
+		"name":        "agent-example",
+		"agent_image": "registry.example.com/agent:1.4.0",
+	})
+	d.Set("user_data", generateTestAgentUserData())
+
+	if err := setAgentArtifacts(d); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, key := range []string{"kubernetes_manifest", "docker_compose", "cloud_init"} {
+		if !strings.Contains(d.Get(key).(string), "registry.example.com/agent:1.4.0") {
+			t.Errorf("%s was not rendered with the agent image", key)
+		}
+	}
+
+	// The task definition is only rendered once the secret holding the user data is known
+	if d.Get("ecs_task_definition").(string) != "" {
+		t.Errorf("ECS task definition should be empty without a secret ARN")
+	}
+
+	d.Set("ecs_user_data_secret_arn", "arn:aws:secretsmanager:us-east-1:123456789012:secret:cpln-agent-user-data")
+
+	if err := setAgentArtifacts(d); err != nil || d.Get("ecs_task_definition").(string) == "" {
+		t.Errorf("ECS task definition was not rendered with a secret ARN")
+	}
+
+	// Without an agent image nothing is rendered
+	d.Set("agent_image", "")
+
+	if err := setAgentArtifacts(d); err != nil || d.Get("docker_compose").(string) != "" || d.Get("ecs_task_definition").(string) != "" {
+		t.Errorf("Artifacts should be empty without an agent image")
+	}
+
+	d.Set("agent_image", "registry.example.com/agent:1.4.0")
+
+	// The agent cannot register without its token and hub endpoint
+	d.Set("user_data", `{"agentId":"a1b2c3d4","hubEndpoint":"https://hub.example.com"}`)
+
+	if err := setAgentArtifacts(d); err == nil {
+		t.Errorf("User data without a registration token should be rejected")
+	}
+
+	// Without user data, e.g. after an import, nothing can be rendered
+	d.Set("user_data", "")
+
+	if err := setAgentArtifacts(d); err != nil || d.Get("docker_compose").(string) != "" {
+		t.Errorf("Artifacts should be empty without user data")
+	}
+}
+
+func TestControlPlane_CustomizeDiffAgent(t *testing.T) {
+
+	state := &terraform.InstanceState{
+		ID: "agent-example",
+		Attributes: map[string]string{
+			"id":                  "agent-example",
+			"name":                "agent-example",
+			"agent_image":         "registry.example.com/agent:1.4.0",
+			"user_data":           generateTestAgentUserData(),
+			"kubernetes_manifest": "manifest",
+			"docker_compose":      "compose",
+			"cloud_init":          "cloud-init",
+			"ecs_task_definition": "",
+		},
+	}
+
+	diff, err := resourceAgent().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "agent-example",
+		"agent_image": "registry.example.com/agent:1.5.0",
+	}), nil)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, key := range []string{"kubernetes_manifest", "docker_compose", "cloud_init", "ecs_task_definition"} {
+		if attribute, ok := diff.Attributes[key]; !ok || !attribute.NewComputed {
+			t.Errorf("%s should be unknown when the agent image changes", key)
+		}
+	}
+}
+
+/*** Generate ***/
+func generateTestAgentUserData() string {
+
+	userData, _ := json.Marshal(&client.AgentBootstrapConfig{
+		RegistrationToken: GetString(`token-"quoted"`),
+		AgentId:           GetString("a1b2c3d4"),
+		AgentLink:         GetString("/org/example/agent/agent-example"),
+		HubEndpoint:       GetString("https://hub.example.com"),
+	})
+
+	return string(userData)
+}
+
+func generateTestAgentStatus() (*client.AgentStatus, []interface{}) {
+
+	status := &client.AgentStatus{
+		BootstrapConfig: &client.AgentBootstrapConfig{
+			AgentId: GetString("a1b2c3d4"),
+		},
+		LastHeartbeat: GetString("2024-05-01T10:00:00.000Z"),
+		Connected:     GetBool(true),
+		Version:       GetString("1.4.0"),
+		PeerCount:     GetInt(2),
+		NetworkResources: &[]client.AgentNetworkResourceStatus{
+			{
+				IdentityLink: GetString("/org/example/gvc/gvc-example/identity/identity-example"),
+				Name:         GetString("postgres"),
+			},
+		},
+	}
+
+	flattened := []interface{}{
+		map[string]interface{}{
+			"connected":      true,
+			"last_heartbeat": "2024-05-01T10:00:00.000Z",
+			"version":        "1.4.0",
+			"peer_count":     2,
+			"active_network_resources": []interface{}{
+				map[string]interface{}{
+					"identity_link": "/org/example/gvc/gvc-example/identity/identity-example",
+					"name":          "postgres",
+				},
+			},
+		},
+	}
+
+	return status, flattened
+}
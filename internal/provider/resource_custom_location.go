@@ -0,0 +1,279 @@
+package cpln
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const customLocationProvider = "byok"
+
+func resourceCustomLocation() *schema.Resource {
+
+	// Geo is provided by the user for custom locations
+	geo := client.GeoSchema()
+	geo.Optional = true
+	geo.MaxItems = 1
+
+	return &schema.Resource{
+		CreateContext: resourceCustomLocationCreate,
+		ReadContext:   resourceCustomLocationRead,
+		UpdateContext: resourceCustomLocationUpdate,
+		DeleteContext: resourceCustomLocationDelete,
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     DescriptionValidator,
+				DiffSuppressFunc: DiffSuppressDescription,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: TagValidator,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"geo": geo,
+			"cloud_provider": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ip_ranges": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bootstrap_config": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{},
+	}
+}
+
+func resourceCustomLocationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	location := client.Location{}
+	location.Name = GetString(d.Get("name"))
+	location.Description = GetString(d.Get("description"))
+	location.Tags = GetStringMap(d.Get("tags"))
+	location.Provider = GetString(customLocationProvider)
+	location.Spec = &client.LocationSpec{
+		Enabled: GetBool(d.Get("enabled")),
+		Geo:     buildLocationGeo(d.Get("geo").([]interface{})),
+	}
+
+	c := m.(*client.Client)
+	newLocation, code, err := c.CreateCustomLocation(location)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The bootstrap config is only available on create
+	if newLocation.Status != nil && newLocation.Status.BootstrapConfig != nil {
+
+		b, err := json.Marshal(newLocation.Status.BootstrapConfig)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("bootstrap_config", string(b)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return setCustomLocation(d, newLocation)
+}
+
+func resourceCustomLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	location, code, err := c.GetLocation(d.Id())
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if location.Provider == nil || *location.Provider != customLocationProvider {
+		return diag.FromErr(fmt.Errorf("location '%s' is not a custom location. Use the cpln_location resource to manage built-in locations", d.Id()))
+	}
+
+	return setCustomLocation(d, location)
+}
+
+func resourceCustomLocationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("description", "tags", "enabled", "geo") {
+
+		locationToUpdate := client.Location{}
+		locationToUpdate.Name = GetString(d.Get("name"))
+
+		if d.HasChange("description") {
+			locationToUpdate.Description = GetDescriptionString(d.Get("description"), *locationToUpdate.Name)
+		}
+
+		if d.HasChange("tags") {
+			locationToUpdate.Tags = GetTagChanges(d)
+		}
+
+		if d.HasChanges("enabled", "geo") {
+			locationToUpdate.Spec = &client.LocationSpec{
+				Enabled: GetBool(d.Get("enabled")),
+				Geo:     buildLocationGeo(d.Get("geo").([]interface{})),
+			}
+		}
+
+		c := m.(*client.Client)
+		updatedLocation, _, err := c.UpdateLocation(locationToUpdate)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setCustomLocation(d, updatedLocation)
+	}
+
+	return nil
+}
+
+func resourceCustomLocationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	err := c.DeleteLocation(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setCustomLocation(d *schema.ResourceData, location *client.Location) diag.Diagnostics {
+
+	if location == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(*location.Name)
+
+	if err := SetBase(d, location.Base); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("cloud_provider", location.Provider); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("region", location.Region); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var geo *client.LocationGeo
+
+	if location.Spec != nil {
+
+		if err := d.Set("enabled", location.Spec.Enabled); err != nil {
+			return diag.FromErr(err)
+		}
+
+		geo = location.Spec.Geo
+	}
+
+	if location.Status != nil {
+
+		// Fall back to the geo reported by the platform
+		if geo == nil {
+			geo = location.Status.Geo
+		}
+
+		if location.Status.IpRanges != nil {
+			if err := d.Set("ip_ranges", flattenIpRanges(location.Status.IpRanges)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if err := d.Set("geo", flattenLocationGeo(geo)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := SetSelfLink(location.Links, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+/*** Build ***/
+
+func buildLocationGeo(specs []interface{}) *client.LocationGeo {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.LocationGeo{}
+
+	if spec["lat"] != nil {
+		lat := float32(spec["lat"].(float64))
+		output.Lat = &lat
+	}
+
+	if spec["lon"] != nil {
+		lon := float32(spec["lon"].(float64))
+		output.Lon = &lon
+	}
+
+	output.Country = GetString(spec["country"])
+	output.State = GetString(spec["state"])
+	output.City = GetString(spec["city"])
+	output.Continent = GetString(spec["continent"])
+
+	return &output
+}
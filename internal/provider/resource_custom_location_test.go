@@ -0,0 +1,190 @@
+package cpln
+
+import (
+	"fmt"
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+/*** Acc Provider Tests ***/
+
+func TestAccControlPlaneCustomLocation_basic(t *testing.T) {
+
+	var testLocation client.Location
+
+	name := "custom-location-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t, "CUSTOM_LOCATION") },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckControlPlaneCustomLocationCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlPlaneCustomLocation(name, "Custom location created using terraform for acceptance tests", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneCustomLocationExists("cpln_custom_location.new", name, &testLocation),
+					resource.TestCheckResourceAttr("cpln_custom_location.new", "cloud_provider", "byok"),
+					resource.TestCheckResourceAttr("cpln_custom_location.new", "enabled", "true"),
+					resource.TestCheckResourceAttr("cpln_custom_location.new", "geo.0.city", "Los Angeles"),
+					resource.TestCheckResourceAttrSet("cpln_custom_location.new", "bootstrap_config"),
+				),
+			},
+			{
+				Config: testAccControlPlaneCustomLocation(name, "Custom location updated using terraform for acceptance tests", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneCustomLocationExists("cpln_custom_location.new", name, &testLocation),
+					resource.TestCheckResourceAttr("cpln_custom_location.new", "description", "Custom location updated using terraform for acceptance tests"),
+					resource.TestCheckResourceAttr("cpln_custom_location.new", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlPlaneCustomLocation(name, description string, enabled bool) string {
+
+	TestLogger.Printf("Inside testAccControlPlaneCustomLocation")
+
+	return fmt.Sprintf(`
+
+	resource "cpln_custom_location" "new" {
+		name        = "%s"
+		description = "%s"
+		enabled     = %t
+
+		tags = {
+		  terraform_generated = "true"
+		  acceptance_test = "true"
+		}
+
+		geo {
+			lat       = 34.0522
+			lon       = -118.2437
+			country   = "USA"
+			state     = "California"
+			city      = "Los Angeles"
+			continent = "North America"
+		}
+	}
+	`, name, description, enabled)
+}
+
+func testAccCheckControlPlaneCustomLocationExists(resourceName, locationName string, location *client.Location) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		TestLogger.Printf("Inside testAccCheckControlPlaneCustomLocationExists. Resources Length: %d", len(s.RootModule().Resources))
+
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", s)
+		}
+
+		if rs.Primary.ID != locationName {
+			return fmt.Errorf("Custom location name does not match")
+		}
+
+		client := testAccProvider.Meta().(*client.Client)
+
+		l, _, err := client.GetLocation(locationName)
+
+		if err != nil {
+			return err
+		}
+
+		if *l.Name != locationName {
+			return fmt.Errorf("Custom location name does not match")
+		}
+
+		*location = *l
+
+		return nil
+	}
+}
+
+func testAccCheckControlPlaneCustomLocationCheckDestroy(s *terraform.State) error {
+
+	if len(s.RootModule().Resources) == 0 {
+		return fmt.Errorf("Error In CheckDestroy. No Resources To Verify")
+	}
+
+	c := testAccProvider.Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "cpln_custom_location" {
+			continue
+		}
+
+		locationName := rs.Primary.ID
+
+		location, _, _ := c.GetLocation(locationName)
+		if location != nil {
+			return fmt.Errorf("Custom location still exists. Name: %s", *location.Name)
+		}
+	}
+
+	return nil
+}
+
+/*** Unit Tests ***/
+// Build //
+func TestControlPlane_BuildLocationGeo(t *testing.T) {
+
+	geo, expectedGeo, _ := generateTestLocationGeo()
+
+	if diff := deep.Equal(geo, expectedGeo); diff != nil {
+		t.Errorf("Location Geo was not built correctly, Diff: %s", diff)
+	}
+}
+
+// Flatten //
+func TestControlPlane_FlattenLocationGeo(t *testing.T) {
+
+	_, expectedGeo, expectedFlatten := generateTestLocationGeo()
+	flattenedGeo := flattenLocationGeo(expectedGeo)
+
+	if diff := deep.Equal(expectedFlatten, flattenedGeo); diff != nil {
+		t.Errorf("Location Geo was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+/*** Generate ***/
+func generateTestLocationGeo() (*client.LocationGeo, *client.LocationGeo, []interface{}) {
+
+	lat := float32(34.0522)
+	lon := float32(-118.2437)
+	country := "USA"
+	state := "California"
+	city := "Los Angeles"
+	continent := "North America"
+
+	flattened := []interface{}{
+		map[string]interface{}{
+			"lat":       34.0522,
+			"lon":       -118.2437,
+			"country":   country,
+			"state":     state,
+			"city":      city,
+			"continent": continent,
+		},
+	}
+
+	geo := buildLocationGeo(flattened)
+	expectedGeo := client.LocationGeo{
+		Lat:       &lat,
+		Lon:       &lon,
+		Country:   &country,
+		State:     &state,
+		City:      &city,
+		Continent: &continent,
+	}
+
+	return geo, &expectedGeo, flattened
+}
@@ -2,9 +2,13 @@ package cpln
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
@@ -12,6 +16,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// identityLocks - Serializes read-modify-write updates of the network resources of an identity, keyed by gvc and identity name
+var identityLocks = &sync.Map{}
+
 func resourceIdentity() *schema.Resource {
 
 	return &schema.Resource{
@@ -19,6 +26,7 @@ func resourceIdentity() *schema.Resource {
 		ReadContext:   resourceIdentityRead,
 		UpdateContext: resourceIdentityUpdate,
 		DeleteContext: resourceIdentityDelete,
+		CustomizeDiff: customizeDiffIdentity,
 		Schema: map[string]*schema.Schema{
 			"gvc": {
 				Type:         schema.TypeString,
@@ -64,41 +72,7 @@ func resourceIdentity() *schema.Resource {
 			"network_resource": {
 				Type:     schema.TypeList,
 				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"agent_link": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: LinkValidator,
-						},
-						"fqdn": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"resolver_ip": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
-						"ips": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
-						"ports": {
-							Type:     schema.TypeSet,
-							Optional: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeInt,
-							},
-						},
-					},
-				},
+				Elem:     NetworkResourceSchema(),
 			},
 			"aws_access_policy": {
 				Type:     schema.TypeList,
@@ -141,23 +115,30 @@ func resourceIdentity() *schema.Resource {
 								return
 							},
 						},
-						// "trust_policy": {
-						// 	Type:     schema.TypeList,
-						// 	Optional: true,
-						// 	MaxItems: 1,
-						// 	Elem: &schema.Resource{
-						// 		Schema: map[string]*schema.Schema{
-						// 			"version": {
-						// 				Type:     schema.TypeString,
-						// 				Optional: true,
-						// 			},
-						// 			"statement": {
-						// 				Type:     schema.TypeString,
-						// 				Optional: true,
-						// 			},
-						// 		},
-						// 	},
-						// },
+						"trust_policy": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     awsPolicyDocumentResource(),
+						},
+						"trust_policy_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     AwsPolicyDocumentValidator,
+							DiffSuppressFunc: diffSuppressAwsPolicyDocument,
+						},
+						"inline_policy": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     awsPolicyDocumentResource(),
+						},
+						"inline_policy_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     AwsPolicyDocumentValidator,
+							DiffSuppressFunc: diffSuppressAwsPolicyDocument,
+						},
 					},
 				},
 			},
@@ -324,6 +305,148 @@ func resourceIdentity() *schema.Resource {
 	}
 }
 
+func awsPolicyDocumentResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "2012-10-17",
+			},
+			"statement": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sid": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"effect": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "Allow",
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+								v := val.(string)
+
+								if v != "Allow" && v != "Deny" {
+									errs = append(errs, fmt.Errorf("%q must be either 'Allow' or 'Deny', got: %s", key, v))
+								}
+
+								return
+							},
+						},
+						"principal":     awsPolicyPrincipalSchema(),
+						"not_principal": awsPolicyPrincipalSchema(),
+						"action": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     StringSchema(),
+						},
+						"not_action": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     StringSchema(),
+						},
+						"resource": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     StringSchema(),
+						},
+						"not_resource": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     StringSchema(),
+						},
+						"condition": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"test": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"variable": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"values": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem:     StringSchema(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// awsPolicyPrincipalSchema - Principals by type, the anonymous principal "*" is declared with the type "*" and the identifier "*"
+func awsPolicyPrincipalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"identifiers": {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem:     StringSchema(),
+				},
+			},
+		},
+	}
+}
+
+func NetworkResourceSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"agent_link": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: LinkValidator,
+			},
+			"fqdn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"resolver_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ips": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ports": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
 func NativeNetworkResourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -394,6 +517,48 @@ func permResource() *schema.Resource {
 	}
 }
 
+// diffSuppressAwsPolicyDocument - Policy documents are compared in their normalized form, ignoring formatting, key order and list order
+func diffSuppressAwsPolicyDocument(k, old, new string, d *schema.ResourceData) bool {
+
+	if old == "" || new == "" {
+		return old == new
+	}
+
+	oldDocument, err := ParseAwsPolicyDocument(old)
+
+	if err != nil {
+		return false
+	}
+
+	newDocument, err := ParseAwsPolicyDocument(new)
+
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(oldDocument, newDocument)
+}
+
+// getAwsPolicyJsonForms - Collects which policy documents of the AWS access policy are defined as JSON in the configuration
+func getAwsPolicyJsonForms(awsIdentities []interface{}) map[string]bool {
+
+	output := map[string]bool{}
+
+	if len(awsIdentities) == 0 || awsIdentities[0] == nil {
+		return output
+	}
+
+	a := awsIdentities[0].(map[string]interface{})
+
+	for _, key := range []string{"trust_policy", "inline_policy"} {
+		if document, ok := a[key+"_json"].(string); ok && strings.TrimSpace(document) != "" {
+			output[key] = true
+		}
+	}
+
+	return output
+}
+
 func importStateIdentity(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 	parts := strings.SplitN(d.Id(), ":", 2)
@@ -408,6 +573,202 @@ func importStateIdentity(ctx context.Context, d *schema.ResourceData, meta inter
 	return []*schema.ResourceData{d}, nil
 }
 
+// identityAccessPolicies - The cloud account provider each access policy block must be linked to
+var identityAccessPolicies = []struct {
+	Block    string
+	Provider string
+}{
+	{Block: "aws_access_policy", Provider: "aws"},
+	{Block: "azure_access_policy", Provider: "azure"},
+	{Block: "gcp_access_policy", Provider: "gcp"},
+	{Block: "ngs_access_policy", Provider: "ngs"},
+}
+
+var awsPolicyArnRegex = regexp.MustCompile(`^arn:aws(-cn|-us-gov)?:iam::(aws|[0-9]{12}):policy/([\w+=,.@-]+/)*[\w+=,.@-]+$`)
+var awsPolicyNameRegex = regexp.MustCompile(`^(aws::)?/?([\w+=,.@-]+/)*[\w+=,.@-]+$`)
+
+func customizeDiffIdentity(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+
+	if diff.NewValueKnown("aws_access_policy") {
+		if policies := diff.Get("aws_access_policy").([]interface{}); len(policies) > 0 && policies[0] != nil {
+			if err := validateAwsAccessPolicy(policies[0].(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+
+	var c *client.Client
+
+	for _, accessPolicy := range identityAccessPolicies {
+
+		if !diff.HasChange(accessPolicy.Block) || !diff.NewValueKnown(accessPolicy.Block) {
+			continue
+		}
+
+		policies := diff.Get(accessPolicy.Block).([]interface{})
+
+		if len(policies) == 0 || policies[0] == nil {
+			continue
+		}
+
+		cloudAccountLink := policies[0].(map[string]interface{})["cloud_account_link"].(string)
+
+		if cloudAccountLink == "" {
+			continue
+		}
+
+		if c == nil {
+			c = m.(*client.Client)
+		}
+
+		cloudAccount, code, err := c.GetCloudAccount(GetNameFromSelfLink(cloudAccountLink))
+
+		if code == 404 {
+			return fmt.Errorf("%s - cloud account '%s' does not exist", accessPolicy.Block, cloudAccountLink)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := validateIdentityCloudAccount(accessPolicy.Block, accessPolicy.Provider, cloudAccount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateAwsAccessPolicy - An AWS access policy either assumes an existing role or is granted policies, and each policy must be an ARN or a policy name
+func validateAwsAccessPolicy(policy map[string]interface{}) error {
+
+	policyRefs := []interface{}{}
+
+	if policy["policy_refs"] != nil {
+		policyRefs = policy["policy_refs"].(*schema.Set).List()
+	}
+
+	if policy["role_name"] != nil && strings.TrimSpace(policy["role_name"].(string)) != "" && len(policyRefs) > 0 {
+		return fmt.Errorf("aws_access_policy - 'role_name' and 'policy_refs' cannot both be set. Either reference an existing role or the policies to grant")
+	}
+
+	for _, key := range []string{"trust_policy", "inline_policy"} {
+
+		block, _ := policy[key].([]interface{})
+		document, _ := policy[key+"_json"].(string)
+
+		if len(block) > 0 && strings.TrimSpace(document) != "" {
+			return fmt.Errorf("aws_access_policy - '%s' and '%s_json' cannot both be set", key, key)
+		}
+
+		if policy["role_name"] != nil && strings.TrimSpace(policy["role_name"].(string)) != "" && (len(block) > 0 || strings.TrimSpace(document) != "") {
+			return fmt.Errorf("aws_access_policy - '%s' cannot be set when referencing an existing role using 'role_name'", key)
+		}
+
+		if blockDocument := buildAwsPolicyDocument(block); blockDocument != nil {
+			for _, statement := range *blockDocument.Statement {
+				if err := validateAwsPolicyStatement(statement); err != nil {
+					return fmt.Errorf("aws_access_policy - %s: %s", key, err)
+				}
+			}
+		}
+	}
+
+	for _, policyRef := range policyRefs {
+
+		ref := policyRef.(string)
+
+		if strings.HasPrefix(ref, "arn:") {
+			if !awsPolicyArnRegex.MatchString(ref) {
+				return fmt.Errorf("aws_access_policy - policy ref '%s' is not a valid IAM policy ARN (e.g. arn:aws:iam::123456789012:policy/my-policy)", ref)
+			}
+
+			continue
+		}
+
+		if !awsPolicyNameRegex.MatchString(ref) {
+			return fmt.Errorf("aws_access_policy - policy ref '%s' must be an IAM policy ARN, a policy name or an AWS managed policy (e.g. aws::ReadOnlyAccess)", ref)
+		}
+	}
+
+	return nil
+}
+
+func validateIdentityCloudAccount(block string, provider string, cloudAccount *client.CloudAccount) error {
+
+	if cloudAccount == nil || cloudAccount.Provider == nil {
+		return nil
+	}
+
+	if *cloudAccount.Provider != provider {
+		return fmt.Errorf("%s - cloud account '%s' belongs to provider '%s', expected a '%s' cloud account", block, *cloudAccount.Name, *cloudAccount.Provider, provider)
+	}
+
+	return nil
+}
+
+// identityCloudAccountWarnings - Warn about linked cloud accounts that Control Plane cannot currently use
+func identityCloudAccountWarnings(d *schema.ResourceData, c *client.Client) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	for _, accessPolicy := range identityAccessPolicies {
+
+		policies := d.Get(accessPolicy.Block).([]interface{})
+
+		if len(policies) == 0 || policies[0] == nil {
+			continue
+		}
+
+		cloudAccountLink := policies[0].(map[string]interface{})["cloud_account_link"].(string)
+		cloudAccount, _, err := c.GetCloudAccount(GetNameFromSelfLink(cloudAccountLink))
+
+		if err != nil {
+			continue
+		}
+
+		if warning := cloudAccountStatusWarning(accessPolicy.Block, cloudAccount); warning != nil {
+			diags = append(diags, *warning)
+		}
+	}
+
+	return diags
+}
+
+func cloudAccountStatusWarning(block string, cloudAccount *client.CloudAccount) *diag.Diagnostic {
+
+	if cloudAccount == nil || cloudAccount.Status == nil {
+		return nil
+	}
+
+	usable := cloudAccount.Status.Usable == nil || *cloudAccount.Status.Usable
+	lastError := ""
+
+	if cloudAccount.Status.LastError != nil {
+		lastError = strings.TrimSpace(*cloudAccount.Status.LastError)
+	}
+
+	if usable && lastError == "" {
+		return nil
+	}
+
+	detail := fmt.Sprintf("Cloud account '%s' referenced by '%s' is not usable by Control Plane.", *cloudAccount.Name, block)
+
+	if usable {
+		detail = fmt.Sprintf("Cloud account '%s' referenced by '%s' reported an error during its last check.", *cloudAccount.Name, block)
+	}
+
+	if lastError != "" {
+		detail = fmt.Sprintf("%s Last error: %s", detail, lastError)
+	}
+
+	return &diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Cloud account check failed",
+		Detail:   detail,
+	}
+}
+
 func resourceIdentityCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// log.Printf("[INFO] Method: resourceIdentityCreate")
@@ -438,7 +799,11 @@ func resourceIdentityCreate(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	return setIdentity(d, newIdentity, gvcName)
+	if e := setIdentity(d, newIdentity, gvcName); e != nil {
+		return e
+	}
+
+	return identityCloudAccountWarnings(d, c)
 }
 
 func buildNetworkResources(networkResources []interface{}, identity *client.Identity) {
@@ -446,45 +811,47 @@ func buildNetworkResources(networkResources []interface{}, identity *client.Iden
 	newNetworkResources := []client.NetworkResource{}
 
 	for _, networkresource := range networkResources {
+		newNetworkResources = append(newNetworkResources, buildNetworkResource(networkresource.(map[string]interface{})))
+	}
 
-		n := networkresource.(map[string]interface{})
+	identity.NetworkResources = &newNetworkResources
+}
 
-		newNetworkResource := client.NetworkResource{
-			Name:      GetString(n["name"]),
-			AgentLink: GetString(n["agent_link"]),
-		}
+func buildNetworkResource(n map[string]interface{}) client.NetworkResource {
 
-		newNetworkResource.FQDN = GetString(n["fqdn"])
-		newNetworkResource.ResolverIP = GetString(n["resolver_ip"])
+	newNetworkResource := client.NetworkResource{
+		Name:      GetString(n["name"]),
+		AgentLink: GetString(n["agent_link"]),
+	}
 
-		if n["ips"] != nil {
-			ips := []string{}
+	newNetworkResource.FQDN = GetString(n["fqdn"])
+	newNetworkResource.ResolverIP = GetString(n["resolver_ip"])
 
-			for _, value := range n["ips"].(*schema.Set).List() {
-				ips = append(ips, value.(string))
-			}
+	if n["ips"] != nil {
+		ips := []string{}
 
-			if len(ips) > 0 {
-				newNetworkResource.IPs = &ips
-			}
+		for _, value := range n["ips"].(*schema.Set).List() {
+			ips = append(ips, value.(string))
 		}
 
-		if n["ports"] != nil {
-			ports := []int{}
+		if len(ips) > 0 {
+			newNetworkResource.IPs = &ips
+		}
+	}
 
-			for _, value := range n["ports"].(*schema.Set).List() {
-				ports = append(ports, value.(int))
-			}
+	if n["ports"] != nil {
+		ports := []int{}
 
-			if len(ports) > 0 {
-				newNetworkResource.Ports = &ports
-			}
+		for _, value := range n["ports"].(*schema.Set).List() {
+			ports = append(ports, value.(int))
 		}
 
-		newNetworkResources = append(newNetworkResources, newNetworkResource)
+		if len(ports) > 0 {
+			newNetworkResource.Ports = &ports
+		}
 	}
 
-	identity.NetworkResources = &newNetworkResources
+	return newNetworkResource
 }
 
 func buildNativeNetworkResources(specs interface{}) *[]client.NativeNetworkResource {
@@ -568,6 +935,9 @@ func buildAwsIdentity(awsIdentities []interface{}, identity *client.Identity, up
 			}
 		}
 
+		newAwsIdentity.TrustPolicy = buildAwsPolicyDocumentFromConfig(a["trust_policy"], a["trust_policy_json"])
+		newAwsIdentity.InlinePolicy = buildAwsPolicyDocumentFromConfig(a["inline_policy"], a["inline_policy_json"])
+
 		if a["policy_refs"] != nil {
 
 			prs := a["policy_refs"].(*schema.Set).List()
@@ -602,6 +972,124 @@ func buildAwsIdentity(awsIdentities []interface{}, identity *client.Identity, up
 	}
 }
 
+// buildAwsPolicyDocumentFromConfig - A policy document is either defined using a block or as a JSON document
+func buildAwsPolicyDocumentFromConfig(block interface{}, document interface{}) *client.AwsPolicyDocument {
+
+	if document != nil && strings.TrimSpace(document.(string)) != "" {
+
+		// The document has already been validated by AwsPolicyDocumentValidator
+		output, _ := ParseAwsPolicyDocument(document.(string))
+
+		return output
+	}
+
+	if block == nil {
+		return nil
+	}
+
+	return buildAwsPolicyDocument(block.([]interface{}))
+}
+
+func buildAwsPolicyDocument(specs []interface{}) *client.AwsPolicyDocument {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.AwsPolicyDocument{
+		Version: GetString(spec["version"]),
+	}
+
+	statements := []client.AwsPolicyStatement{}
+
+	for _, value := range spec["statement"].([]interface{}) {
+
+		st := value.(map[string]interface{})
+		statement := client.AwsPolicyStatement{
+			Sid:    GetString(st["sid"]),
+			Effect: GetString(st["effect"]),
+		}
+
+		statement.Principal = buildAwsPolicyPrincipal(st["principal"])
+		statement.NotPrincipal = buildAwsPolicyPrincipal(st["not_principal"])
+
+		if actions := buildAwsPolicyStringList(st["action"]); len(actions) > 0 {
+			statement.Action = &actions
+		}
+
+		if notActions := buildAwsPolicyStringList(st["not_action"]); len(notActions) > 0 {
+			statement.NotAction = &notActions
+		}
+
+		if resources := buildAwsPolicyStringList(st["resource"]); len(resources) > 0 {
+			statement.Resource = &resources
+		}
+
+		if notResources := buildAwsPolicyStringList(st["not_resource"]); len(notResources) > 0 {
+			statement.NotResource = &notResources
+		}
+
+		if conditions := st["condition"].(*schema.Set).List(); len(conditions) > 0 {
+
+			condition := map[string]map[string]client.AwsPolicyConditionValues{}
+
+			for _, c := range conditions {
+
+				cd := c.(map[string]interface{})
+				test := cd["test"].(string)
+
+				if condition[test] == nil {
+					condition[test] = map[string]client.AwsPolicyConditionValues{}
+				}
+
+				condition[test][cd["variable"].(string)] = client.AwsPolicyConditionValues(buildAwsPolicyStringList(cd["values"]))
+			}
+
+			statement.Condition = &condition
+		}
+
+		statements = append(statements, statement)
+	}
+
+	output.Statement = &statements
+
+	NormalizeAwsPolicyDocument(&output)
+
+	return &output
+}
+
+func buildAwsPolicyPrincipal(set interface{}) *client.AwsPolicyPrincipal {
+
+	if set == nil || set.(*schema.Set).Len() == 0 {
+		return nil
+	}
+
+	output := client.AwsPolicyPrincipal{}
+
+	for _, p := range set.(*schema.Set).List() {
+		pr := p.(map[string]interface{})
+		output[pr["type"].(string)] = buildAwsPolicyStringList(pr["identifiers"])
+	}
+
+	return &output
+}
+
+func buildAwsPolicyStringList(set interface{}) client.AwsPolicyStringList {
+
+	output := client.AwsPolicyStringList{}
+
+	if set == nil {
+		return output
+	}
+
+	for _, value := range set.(*schema.Set).List() {
+		output = append(output, value.(string))
+	}
+
+	return output
+}
+
 func buildAzureIdentity(azureIdentities []interface{}, identity *client.Identity, update bool) {
 
 	if len(azureIdentities) == 1 {
@@ -875,43 +1363,47 @@ func flattenNetworkResources(networkResources *[]client.NetworkResource) []inter
 		nrs := make([]interface{}, len(*networkResources))
 
 		for i, networkResource := range *networkResources {
+			nrs[i] = flattenNetworkResource(networkResource)
+		}
 
-			nr := make(map[string]interface{})
+		return nrs
+	}
 
-			nr["name"] = networkResource.Name
-			nr["agent_link"] = networkResource.AgentLink
+	return make([]interface{}, 0)
+}
 
-			if networkResource.FQDN != nil {
-				nr["fqdn"] = networkResource.FQDN
-			}
+func flattenNetworkResource(networkResource client.NetworkResource) map[string]interface{} {
 
-			if networkResource.ResolverIP != nil {
-				nr["resolver_ip"] = networkResource.ResolverIP
-			}
+	nr := make(map[string]interface{})
 
-			if networkResource.IPs != nil && len(*networkResource.IPs) > 0 {
-				nr["ips"] = []interface{}{}
+	nr["name"] = networkResource.Name
+	nr["agent_link"] = networkResource.AgentLink
 
-				for _, ip := range *networkResource.IPs {
-					nr["ips"] = append(nr["ips"].([]interface{}), ip)
-				}
-			}
+	if networkResource.FQDN != nil {
+		nr["fqdn"] = networkResource.FQDN
+	}
 
-			if networkResource.Ports != nil && len(*networkResource.Ports) > 0 {
-				nr["ports"] = []interface{}{}
+	if networkResource.ResolverIP != nil {
+		nr["resolver_ip"] = networkResource.ResolverIP
+	}
 
-				for _, port := range *networkResource.Ports {
-					nr["ports"] = append(nr["ports"].([]interface{}), port)
-				}
-			}
+	if networkResource.IPs != nil && len(*networkResource.IPs) > 0 {
+		nr["ips"] = []interface{}{}
 
-			nrs[i] = nr
+		for _, ip := range *networkResource.IPs {
+			nr["ips"] = append(nr["ips"].([]interface{}), ip)
 		}
+	}
 
-		return nrs
+	if networkResource.Ports != nil && len(*networkResource.Ports) > 0 {
+		nr["ports"] = []interface{}{}
+
+		for _, port := range *networkResource.Ports {
+			nr["ports"] = append(nr["ports"].([]interface{}), port)
+		}
 	}
 
-	return make([]interface{}, 0)
+	return nr
 }
 
 func flattenNativeNetworkResources(nativeNetworkResources *[]client.NativeNetworkResource) []interface{} {
@@ -921,23 +1413,29 @@ func flattenNativeNetworkResources(nativeNetworkResources *[]client.NativeNetwor
 
 	collection := make([]interface{}, len(*nativeNetworkResources))
 	for i, item := range *nativeNetworkResources {
+		collection[i] = flattenNativeNetworkResource(item)
+	}
 
-		resource := make(map[string]interface{})
-		resource["name"] = *item.Name
-		resource["fqdn"] = *item.FQDN
-		resource["ports"] = []interface{}{}
+	return collection
+}
+
+func flattenNativeNetworkResource(item client.NativeNetworkResource) map[string]interface{} {
+
+	resource := make(map[string]interface{})
+	resource["name"] = *item.Name
+	resource["fqdn"] = *item.FQDN
+	resource["ports"] = []interface{}{}
 
+	if item.Ports != nil {
 		for _, port := range *item.Ports {
 			resource["ports"] = append(resource["ports"].([]interface{}), port)
 		}
-
-		resource["aws_private_link"] = flattenAWSPrivateLink(item.AWSPrivateLink)
-		resource["gcp_service_connect"] = flattenGCPServiceConnect(item.GCPServiceConnect)
-
-		collection[i] = resource
 	}
 
-	return collection
+	resource["aws_private_link"] = flattenAWSPrivateLink(item.AWSPrivateLink)
+	resource["gcp_service_connect"] = flattenGCPServiceConnect(item.GCPServiceConnect)
+
+	return resource
 }
 
 func flattenAWSPrivateLink(awsPrivateLink *client.AWSPrivateLink) []interface{} {
@@ -966,7 +1464,7 @@ func flattenGCPServiceConnect(gcpServiceConnect *client.GCPServiceConnect) []int
 	}
 }
 
-func flattenAwsIdentity(awsIdentity *client.AwsIdentity) []interface{} {
+func flattenAwsIdentity(awsIdentity *client.AwsIdentity, policyJsonForms map[string]bool) []interface{} {
 
 	if awsIdentity != nil {
 
@@ -988,6 +1486,9 @@ func flattenAwsIdentity(awsIdentity *client.AwsIdentity) []interface{} {
 			output["role_name"] = *awsIdentity.RoleName
 		}
 
+		flattenAwsPolicyDocumentInto(output, "trust_policy", awsIdentity.TrustPolicy, policyJsonForms["trust_policy"])
+		flattenAwsPolicyDocumentInto(output, "inline_policy", awsIdentity.InlinePolicy, policyJsonForms["inline_policy"])
+
 		return []interface{}{
 			output,
 		}
@@ -996,6 +1497,142 @@ func flattenAwsIdentity(awsIdentity *client.AwsIdentity) []interface{} {
 	return nil
 }
 
+// flattenAwsPolicyDocumentInto - The document is flattened into the same form, block or JSON, that is used in the configuration
+func flattenAwsPolicyDocumentInto(output map[string]interface{}, key string, document *client.AwsPolicyDocument, jsonForm bool) {
+
+	if document == nil {
+		return
+	}
+
+	if !jsonForm {
+		output[key] = flattenAwsPolicyDocument(document)
+		return
+	}
+
+	NormalizeAwsPolicyDocument(document)
+
+	if data, err := json.Marshal(document); err == nil {
+		output[key+"_json"] = string(data)
+	}
+}
+
+func flattenAwsPolicyDocument(document *client.AwsPolicyDocument) []interface{} {
+
+	if document == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if document.Version != nil {
+		spec["version"] = *document.Version
+	}
+
+	statements := []interface{}{}
+
+	if document.Statement != nil {
+
+		for _, statement := range *document.Statement {
+
+			st := map[string]interface{}{}
+
+			if statement.Sid != nil {
+				st["sid"] = *statement.Sid
+			}
+
+			if statement.Effect != nil {
+				st["effect"] = *statement.Effect
+			}
+
+			if statement.Principal != nil {
+				st["principal"] = flattenAwsPolicyPrincipal(statement.Principal)
+			}
+
+			if statement.NotPrincipal != nil {
+				st["not_principal"] = flattenAwsPolicyPrincipal(statement.NotPrincipal)
+			}
+
+			if statement.Action != nil {
+				st["action"] = []string(*statement.Action)
+			}
+
+			if statement.NotAction != nil {
+				st["not_action"] = []string(*statement.NotAction)
+			}
+
+			if statement.Resource != nil {
+				st["resource"] = []string(*statement.Resource)
+			}
+
+			if statement.NotResource != nil {
+				st["not_resource"] = []string(*statement.NotResource)
+			}
+
+			if statement.Condition != nil {
+
+				conditions := []interface{}{}
+				tests := []string{}
+
+				for test := range *statement.Condition {
+					tests = append(tests, test)
+				}
+
+				sort.Strings(tests)
+
+				for _, test := range tests {
+
+					variables := []string{}
+
+					for variable := range (*statement.Condition)[test] {
+						variables = append(variables, variable)
+					}
+
+					sort.Strings(variables)
+
+					for _, variable := range variables {
+						conditions = append(conditions, map[string]interface{}{
+							"test":     test,
+							"variable": variable,
+							"values":   []string((*statement.Condition)[test][variable]),
+						})
+					}
+				}
+
+				st["condition"] = conditions
+			}
+
+			statements = append(statements, st)
+		}
+	}
+
+	spec["statement"] = statements
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenAwsPolicyPrincipal(principal *client.AwsPolicyPrincipal) []interface{} {
+
+	output := []interface{}{}
+	types := []string{}
+
+	for principalType := range *principal {
+		types = append(types, principalType)
+	}
+
+	sort.Strings(types)
+
+	for _, principalType := range types {
+		output = append(output, map[string]interface{}{
+			"type":        principalType,
+			"identifiers": []string((*principal)[principalType]),
+		})
+	}
+
+	return output
+}
+
 func flattenAzureIdentity(azureIdentity *client.AzureIdentity) []interface{} {
 
 	if azureIdentity != nil {
@@ -1210,19 +1847,25 @@ func setIdentity(d *schema.ResourceData, identity *client.Identity, gvcName stri
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("tags", getIdentityTags(identity.Tags)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("status", flattenIdentityStatus(identity.Status)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("network_resource", flattenNetworkResources(identity.NetworkResources)); err != nil {
+	// Every network resource is set except the ones owned by cpln_identity_network_resource and cpln_identity_native_network_resource,
+	// so entries added outside of Terraform show up as drift
+	if err := d.Set("network_resource", flattenNetworkResources(filterNetworkResources(identity.NetworkResources, getOwnedNetworkResourceNames(identity.Tags, client.IdentityNetworkResourceOwnerTagPrefix)))); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("native_network_resource", flattenNativeNetworkResources(identity.NativeNetworkResources)); err != nil {
+	if err := d.Set("native_network_resource", flattenNativeNetworkResources(filterNativeNetworkResources(identity.NativeNetworkResources, getOwnedNetworkResourceNames(identity.Tags, client.IdentityNativeNetworkResourceOwnerTagPrefix)))); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("aws_access_policy", flattenAwsIdentity(identity.Aws)); err != nil {
+	if err := d.Set("aws_access_policy", flattenAwsIdentity(identity.Aws, getAwsPolicyJsonForms(d.Get("aws_access_policy").([]interface{})))); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -1275,12 +1918,29 @@ func resourceIdentityUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			identityToUpdate.Tags = GetTagChanges(d)
 		}
 
-		if d.HasChange("network_resource") {
-			buildNetworkResources(d.Get("network_resource").([]interface{}), &identityToUpdate)
-		}
+		c := m.(*client.Client)
+
+		// Network resources can also be added by cpln_identity_network_resource and cpln_identity_native_network_resource,
+		// keep the ones they own and replace the rest with the declared ones
+		if d.HasChanges("network_resource", "native_network_resource") {
+
+			defer lockIdentity(gvcName, *identityToUpdate.Name)()
+
+			identity, _, err := c.GetIdentity(*identityToUpdate.Name, gvcName)
+
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			if d.HasChange("network_resource") {
+				buildNetworkResources(d.Get("network_resource").([]interface{}), &identityToUpdate)
+				identityToUpdate.NetworkResources = mergeNetworkResources(identityToUpdate.NetworkResources, identity.NetworkResources, getOwnedNetworkResourceNames(identity.Tags, client.IdentityNetworkResourceOwnerTagPrefix))
+			}
 
-		if d.HasChange("native_network_resource") {
-			identityToUpdate.NativeNetworkResources = buildNativeNetworkResources(d.Get("native_network_resource"))
+			if d.HasChange("native_network_resource") {
+				identityToUpdate.NativeNetworkResources = buildNativeNetworkResources(d.Get("native_network_resource"))
+				identityToUpdate.NativeNetworkResources = mergeNativeNetworkResources(identityToUpdate.NativeNetworkResources, identity.NativeNetworkResources, getOwnedNetworkResourceNames(identity.Tags, client.IdentityNativeNetworkResourceOwnerTagPrefix))
+			}
 		}
 
 		if d.HasChange("aws_access_policy") {
@@ -1299,13 +1959,16 @@ func resourceIdentityUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			buildNgsIdentity(d.Get("ngs_access_policy").([]interface{}), &identityToUpdate, true)
 		}
 
-		c := m.(*client.Client)
 		updatedIdentity, _, err := c.UpdateIdentity(identityToUpdate, gvcName)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 
-		return setIdentity(d, updatedIdentity, gvcName)
+		if e := setIdentity(d, updatedIdentity, gvcName); e != nil {
+			return e
+		}
+
+		return identityCloudAccountWarnings(d, c)
 	}
 
 	return nil
@@ -1325,3 +1988,139 @@ func resourceIdentityDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 	return nil
 }
+
+/*** Helpers ***/
+
+// lockIdentity - Locks the network resources of an identity and returns the function that unlocks them
+func lockIdentity(gvcName string, identityName string) func() {
+
+	lock, _ := identityLocks.LoadOrStore(fmt.Sprintf("%s/%s", gvcName, identityName), &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
+// getIdentityTags - The identity tags without the reserved tags recording which network resources are owned by their own resource
+func getIdentityTags(tags *map[string]interface{}) map[string]interface{} {
+
+	output := GetTags(tags)
+
+	for key := range output {
+		if strings.HasPrefix(key, client.IdentityNetworkResourceOwnerTagPrefix) || strings.HasPrefix(key, client.IdentityNativeNetworkResourceOwnerTagPrefix) {
+			delete(output, key)
+		}
+	}
+
+	return output
+}
+
+// getOwnedNetworkResourceNames - Names of the network resources that the standalone network resource resources recorded as theirs in the identity tags
+func getOwnedNetworkResourceNames(tags *map[string]interface{}, prefix string) map[string]bool {
+
+	names := map[string]bool{}
+
+	if tags == nil {
+		return names
+	}
+
+	for key := range *tags {
+		if strings.HasPrefix(key, prefix) {
+			names[strings.TrimPrefix(key, prefix)] = true
+		}
+	}
+
+	return names
+}
+
+// filterNetworkResources - Drops the network resources with the given names
+func filterNetworkResources(networkResources *[]client.NetworkResource, excluded map[string]bool) *[]client.NetworkResource {
+
+	if networkResources == nil {
+		return nil
+	}
+
+	filtered := []client.NetworkResource{}
+
+	for _, networkResource := range *networkResources {
+		if networkResource.Name == nil || !excluded[*networkResource.Name] {
+			filtered = append(filtered, networkResource)
+		}
+	}
+
+	return &filtered
+}
+
+// filterNativeNetworkResources - Drops the native network resources with the given names
+func filterNativeNetworkResources(nativeNetworkResources *[]client.NativeNetworkResource, excluded map[string]bool) *[]client.NativeNetworkResource {
+
+	if nativeNetworkResources == nil {
+		return nil
+	}
+
+	filtered := []client.NativeNetworkResource{}
+
+	for _, nativeNetworkResource := range *nativeNetworkResources {
+		if nativeNetworkResource.Name == nil || !excluded[*nativeNetworkResource.Name] {
+			filtered = append(filtered, nativeNetworkResource)
+		}
+	}
+
+	return &filtered
+}
+
+// mergeNetworkResources - Appends the current network resources owned by the standalone resources to the ones the identity declares,
+// a name declared by the identity takes precedence
+func mergeNetworkResources(networkResources *[]client.NetworkResource, current *[]client.NetworkResource, owned map[string]bool) *[]client.NetworkResource {
+
+	merged := []client.NetworkResource{}
+	declared := map[string]bool{}
+
+	if networkResources != nil {
+		for _, networkResource := range *networkResources {
+			merged = append(merged, networkResource)
+
+			if networkResource.Name != nil {
+				declared[*networkResource.Name] = true
+			}
+		}
+	}
+
+	if current != nil {
+		for _, networkResource := range *current {
+			if networkResource.Name != nil && owned[*networkResource.Name] && !declared[*networkResource.Name] {
+				merged = append(merged, networkResource)
+			}
+		}
+	}
+
+	return &merged
+}
+
+// mergeNativeNetworkResources - Appends the current native network resources owned by the standalone resources to the ones the identity declares,
+// a name declared by the identity takes precedence
+func mergeNativeNetworkResources(nativeNetworkResources *[]client.NativeNetworkResource, current *[]client.NativeNetworkResource, owned map[string]bool) *[]client.NativeNetworkResource {
+
+	merged := []client.NativeNetworkResource{}
+	declared := map[string]bool{}
+
+	if nativeNetworkResources != nil {
+		for _, nativeNetworkResource := range *nativeNetworkResources {
+			merged = append(merged, nativeNetworkResource)
+
+			if nativeNetworkResource.Name != nil {
+				declared[*nativeNetworkResource.Name] = true
+			}
+		}
+	}
+
+	if current != nil {
+		for _, nativeNetworkResource := range *current {
+			if nativeNetworkResource.Name != nil && owned[*nativeNetworkResource.Name] && !declared[*nativeNetworkResource.Name] {
+				merged = append(merged, nativeNetworkResource)
+			}
+		}
+	}
+
+	return &merged
+}
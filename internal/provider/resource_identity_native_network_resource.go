@@ -0,0 +1,202 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Main ***/
+
+// resourceIdentityNativeNetworkResource - Manages a single native network resource of an identity that is owned by a separate configuration
+func resourceIdentityNativeNetworkResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIdentityNativeNetworkResourceCreate,
+		ReadContext:   resourceIdentityNativeNetworkResourceRead,
+		UpdateContext: resourceIdentityNativeNetworkResourceUpdate,
+		DeleteContext: resourceIdentityNativeNetworkResourceDelete,
+		Schema:        identityNetworkResourceSchema(NativeNetworkResourceSchema()),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdentityNativeNetworkResource,
+		},
+	}
+}
+
+func importStateIdentityNativeNetworkResource(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	gvcName, identityName, name, err := parseIdentityNetworkResourceId(d.Id(), "cpln_identity_native_network_resource")
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := meta.(*client.Client)
+	identity, _, err := c.GetIdentity(identityName, gvcName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if findIdentityNativeNetworkResource(identity, name) == nil {
+		return nil, fmt.Errorf("native network resource '%s' does not exist in identity '%s'", name, identityName)
+	}
+
+	defer lockIdentity(gvcName, identityName)()
+
+	// From now on the identity resource leaves this entry alone
+	if _, _, err := c.ClaimIdentityNetworkResource(identityName, gvcName, client.IdentityNativeNetworkResourceOwnerTagPrefix, name); err != nil {
+		return nil, err
+	}
+
+	d.Set("gvc", gvcName)
+	d.Set("identity", identityName)
+	d.Set("name", name)
+	d.SetId(identityNetworkResourceId(gvcName, identityName, name))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIdentityNativeNetworkResourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	identityName := d.Get("identity").(string)
+	nativeNetworkResource := buildIdentityNativeNetworkResource(d)
+
+	defer lockIdentity(gvcName, identityName)()
+
+	c := m.(*client.Client)
+	identity, code, err := c.AddIdentityNativeNetworkResource(identityName, gvcName, nativeNetworkResource)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setIdentityNativeNetworkResource(d, gvcName, identityName, findIdentityNativeNetworkResource(identity, *nativeNetworkResource.Name))
+}
+
+func resourceIdentityNativeNetworkResourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	identityName := d.Get("identity").(string)
+
+	c := m.(*client.Client)
+	identity, code, err := c.GetIdentity(identityName, gvcName)
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setIdentityNativeNetworkResource(d, gvcName, identityName, findIdentityNativeNetworkResource(identity, d.Get("name").(string)))
+}
+
+func resourceIdentityNativeNetworkResourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("fqdn", "ports", "aws_private_link", "gcp_service_connect") {
+
+		gvcName := d.Get("gvc").(string)
+		identityName := d.Get("identity").(string)
+		nativeNetworkResource := buildIdentityNativeNetworkResource(d)
+
+		defer lockIdentity(gvcName, identityName)()
+
+		c := m.(*client.Client)
+		identity, _, err := c.UpdateIdentityNativeNetworkResource(identityName, gvcName, nativeNetworkResource)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setIdentityNativeNetworkResource(d, gvcName, identityName, findIdentityNativeNetworkResource(identity, *nativeNetworkResource.Name))
+	}
+
+	return nil
+}
+
+func resourceIdentityNativeNetworkResourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	identityName := d.Get("identity").(string)
+
+	defer lockIdentity(gvcName, identityName)()
+
+	c := m.(*client.Client)
+	_, code, err := c.RemoveIdentityNativeNetworkResource(identityName, gvcName, d.Get("name").(string))
+
+	// The native network resource or its identity is already gone
+	if err != nil && code != 404 {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setIdentityNativeNetworkResource(d *schema.ResourceData, gvcName string, identityName string, nativeNetworkResource *client.NativeNetworkResource) diag.Diagnostics {
+
+	if nativeNetworkResource == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(identityNetworkResourceId(gvcName, identityName, *nativeNetworkResource.Name))
+
+	if err := d.Set("gvc", gvcName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("identity", identityName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := flattenNativeNetworkResource(*nativeNetworkResource)
+
+	for _, key := range []string{"name", "fqdn", "ports", "aws_private_link", "gcp_service_connect"} {
+		if err := d.Set(key, flattened[key]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+/*** Build ***/
+func buildIdentityNativeNetworkResource(d *schema.ResourceData) client.NativeNetworkResource {
+	return buildNativeNetworkResource(map[string]interface{}{
+		"name":                d.Get("name"),
+		"fqdn":                d.Get("fqdn"),
+		"ports":               d.Get("ports"),
+		"aws_private_link":    d.Get("aws_private_link"),
+		"gcp_service_connect": d.Get("gcp_service_connect"),
+	})
+}
+
+/*** Helpers ***/
+func findIdentityNativeNetworkResource(identity *client.Identity, name string) *client.NativeNetworkResource {
+
+	if identity == nil || identity.NativeNetworkResources == nil {
+		return nil
+	}
+
+	for _, nativeNetworkResource := range *identity.NativeNetworkResources {
+		if nativeNetworkResource.Name != nil && *nativeNetworkResource.Name == name {
+			found := nativeNetworkResource
+			return &found
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Main ***/
+
+// resourceIdentityNetworkResource - Manages a single network resource of an identity that is owned by a separate configuration
+func resourceIdentityNetworkResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIdentityNetworkResourceCreate,
+		ReadContext:   resourceIdentityNetworkResourceRead,
+		UpdateContext: resourceIdentityNetworkResourceUpdate,
+		DeleteContext: resourceIdentityNetworkResourceDelete,
+		Schema:        identityNetworkResourceSchema(NetworkResourceSchema()),
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdentityNetworkResource,
+		},
+	}
+}
+
+// identityNetworkResourceSchema - Adds the arguments that reference the identity to the schema of a network resource block
+func identityNetworkResourceSchema(networkResource *schema.Resource) map[string]*schema.Schema {
+
+	resourceSchema := networkResource.Schema
+
+	resourceSchema["gvc"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: NameValidator,
+	}
+
+	resourceSchema["identity"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: NameValidator,
+	}
+
+	// The name identifies the network resource within the identity
+	resourceSchema["name"].ForceNew = true
+
+	return resourceSchema
+}
+
+func importStateIdentityNetworkResource(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	gvcName, identityName, name, err := parseIdentityNetworkResourceId(d.Id(), "cpln_identity_network_resource")
+
+	if err != nil {
+		return nil, err
+	}
+
+	c := meta.(*client.Client)
+	identity, _, err := c.GetIdentity(identityName, gvcName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if findIdentityNetworkResource(identity, name) == nil {
+		return nil, fmt.Errorf("network resource '%s' does not exist in identity '%s'", name, identityName)
+	}
+
+	defer lockIdentity(gvcName, identityName)()
+
+	// From now on the identity resource leaves this entry alone
+	if _, _, err := c.ClaimIdentityNetworkResource(identityName, gvcName, client.IdentityNetworkResourceOwnerTagPrefix, name); err != nil {
+		return nil, err
+	}
+
+	d.Set("gvc", gvcName)
+	d.Set("identity", identityName)
+	d.Set("name", name)
+	d.SetId(identityNetworkResourceId(gvcName, identityName, name))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIdentityNetworkResourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	identityName := d.Get("identity").(string)
+	networkResource := buildIdentityNetworkResource(d)
+
+	defer lockIdentity(gvcName, identityName)()
+
+	c := m.(*client.Client)
+	identity, code, err := c.AddIdentityNetworkResource(identityName, gvcName, networkResource)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setIdentityNetworkResource(d, gvcName, identityName, findIdentityNetworkResource(identity, *networkResource.Name))
+}
+
+func resourceIdentityNetworkResourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	identityName := d.Get("identity").(string)
+
+	c := m.(*client.Client)
+	identity, code, err := c.GetIdentity(identityName, gvcName)
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setIdentityNetworkResource(d, gvcName, identityName, findIdentityNetworkResource(identity, d.Get("name").(string)))
+}
+
+func resourceIdentityNetworkResourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("agent_link", "fqdn", "resolver_ip", "ips", "ports") {
+
+		gvcName := d.Get("gvc").(string)
+		identityName := d.Get("identity").(string)
+		networkResource := buildIdentityNetworkResource(d)
+
+		defer lockIdentity(gvcName, identityName)()
+
+		c := m.(*client.Client)
+		identity, _, err := c.UpdateIdentityNetworkResource(identityName, gvcName, networkResource)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setIdentityNetworkResource(d, gvcName, identityName, findIdentityNetworkResource(identity, *networkResource.Name))
+	}
+
+	return nil
+}
+
+func resourceIdentityNetworkResourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	identityName := d.Get("identity").(string)
+
+	defer lockIdentity(gvcName, identityName)()
+
+	c := m.(*client.Client)
+	_, code, err := c.RemoveIdentityNetworkResource(identityName, gvcName, d.Get("name").(string))
+
+	// The network resource or its identity is already gone
+	if err != nil && code != 404 {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setIdentityNetworkResource(d *schema.ResourceData, gvcName string, identityName string, networkResource *client.NetworkResource) diag.Diagnostics {
+
+	if networkResource == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(identityNetworkResourceId(gvcName, identityName, *networkResource.Name))
+
+	if err := d.Set("gvc", gvcName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("identity", identityName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := flattenNetworkResource(*networkResource)
+
+	for _, key := range []string{"name", "agent_link", "fqdn", "resolver_ip", "ips", "ports"} {
+		if err := d.Set(key, flattened[key]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+/*** Build ***/
+func buildIdentityNetworkResource(d *schema.ResourceData) client.NetworkResource {
+	return buildNetworkResource(map[string]interface{}{
+		"name":        d.Get("name"),
+		"agent_link":  d.Get("agent_link"),
+		"fqdn":        d.Get("fqdn"),
+		"resolver_ip": d.Get("resolver_ip"),
+		"ips":         d.Get("ips"),
+		"ports":       d.Get("ports"),
+	})
+}
+
+/*** Helpers ***/
+func identityNetworkResourceId(gvcName string, identityName string, name string) string {
+	return fmt.Sprintf("%s:%s:%s", gvcName, identityName, name)
+}
+
+func parseIdentityNetworkResourceId(id string, resourceType string) (string, string, string, error) {
+
+	parts := strings.SplitN(id, ":", 3)
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%s), expected ID syntax: 'gvc:identity:name'. Example: 'terraform import %s.RESOURCE_NAME GVC_NAME:IDENTITY_NAME:NAME'", id, resourceType)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func findIdentityNetworkResource(identity *client.Identity, name string) *client.NetworkResource {
+
+	if identity == nil || identity.NetworkResources == nil {
+		return nil
+	}
+
+	for _, networkResource := range *identity.NetworkResources {
+		if networkResource.Name != nil && *networkResource.Name == name {
+			found := networkResource
+			return &found
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package cpln
+
+import (
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Unit Tests ***/
+func TestControlPlane_SetIdentityNetworkResource(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceIdentityNetworkResource().Schema, map[string]interface{}{})
+	identity := generateTestIdentityWithNetworkResources()
+
+	if diags := setIdentityNetworkResource(d, "gvc-example", "identity-example", findIdentityNetworkResource(identity, "postgres")); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if d.Id() != "gvc-example:identity-example:postgres" {
+		t.Errorf("Unexpected ID: %s", d.Id())
+	}
+
+	if diff := deep.Equal(buildIdentityNetworkResource(d), (*identity.NetworkResources)[1]); diff != nil {
+		t.Errorf("Network resource was not set correctly. Diff: %s", diff)
+	}
+
+	if diags := setIdentityNetworkResource(d, "gvc-example", "identity-example", findIdentityNetworkResource(identity, "missing")); diags.HasError() || d.Id() != "" {
+		t.Errorf("A missing network resource should be removed from the state")
+	}
+}
+
+func TestControlPlane_ParseIdentityNetworkResourceId(t *testing.T) {
+
+	gvcName, identityName, name, err := parseIdentityNetworkResourceId("gvc-example:identity-example:postgres", "cpln_identity_network_resource")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if diff := deep.Equal([]string{gvcName, identityName, name}, []string{"gvc-example", "identity-example", "postgres"}); diff != nil {
+		t.Errorf("ID was not parsed correctly. Diff: %s", diff)
+	}
+
+	for _, id := range []string{"gvc-example:identity-example", "gvc-example::postgres", ""} {
+		if _, _, _, err := parseIdentityNetworkResourceId(id, "cpln_identity_network_resource"); err == nil {
+			t.Errorf("ID '%s' should not be accepted", id)
+		}
+	}
+}
+
+/*** Generate ***/
+func generateTestIdentityWithNetworkResources() *client.Identity {
+
+	return &client.Identity{
+		NetworkResources: &[]client.NetworkResource{
+			{
+				Name:      GetString("redis"),
+				AgentLink: GetString("/org/example/agent/agent-example"),
+				IPs:       &[]string{"10.0.0.10"},
+				Ports:     &[]int{6379},
+			},
+			{
+				Name:       GetString("postgres"),
+				AgentLink:  GetString("/org/example/agent/agent-example"),
+				FQDN:       GetString("db.internal.example.com"),
+				ResolverIP: GetString("10.0.0.2"),
+				Ports:      &[]int{5432},
+			},
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package cpln
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
 	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -391,6 +393,453 @@ func TestControlPlane_BuildGCPServiceConnect(t *testing.T) {
 	}
 }
 
+func TestControlPlane_ValidateAwsAccessPolicy(t *testing.T) {
+
+	policy := map[string]interface{}{
+		"cloud_account_link": "/org/default/cloudaccount/aws",
+		"role_name":          "",
+		"policy_refs": schema.NewSet(schema.HashString, []interface{}{
+			"aws::/job-function/SupportUser",
+			"aws::AWSSupportAccess",
+			"arn:aws:iam::123456789012:policy/team/s3-reader",
+			"arn:aws:iam::aws:policy/ReadOnlyAccess",
+		}),
+	}
+
+	if err := validateAwsAccessPolicy(policy); err != nil {
+		t.Errorf("AWS access policy should be valid, got: %s", err)
+	}
+
+	policy["role_name"] = "rds-monitoring-role"
+
+	if err := validateAwsAccessPolicy(policy); err == nil {
+		t.Errorf("AWS access policy should not allow both role_name and policy_refs")
+	}
+
+	policy["role_name"] = ""
+	policy["policy_refs"] = schema.NewSet(schema.HashString, []interface{}{"arn:aws:iam::1234:policy/s3-reader"})
+
+	if err := validateAwsAccessPolicy(policy); err == nil {
+		t.Errorf("AWS access policy should not allow an invalid policy ARN")
+	}
+
+	policy["policy_refs"] = schema.NewSet(schema.HashString, []interface{}{"read only"})
+
+	if err := validateAwsAccessPolicy(policy); err == nil {
+		t.Errorf("AWS access policy should not allow an invalid policy name")
+	}
+
+	policy["policy_refs"] = schema.NewSet(schema.HashString, []interface{}{})
+	policy["role_name"] = "rds-monitoring-role"
+	policy["trust_policy_json"] = `{"Statement":[{"Effect":"Allow","Action":"sts:AssumeRole"}]}`
+
+	if err := validateAwsAccessPolicy(policy); err == nil {
+		t.Errorf("AWS access policy should not allow a trust policy when referencing an existing role")
+	}
+
+	policy["role_name"] = ""
+	policy["trust_policy"] = []interface{}{map[string]interface{}{"version": "2012-10-17"}}
+
+	if err := validateAwsAccessPolicy(policy); err == nil {
+		t.Errorf("AWS access policy should not allow both trust_policy and trust_policy_json")
+	}
+}
+
+func TestControlPlane_ValidateIdentityCloudAccount(t *testing.T) {
+
+	cloudAccount := &client.CloudAccount{
+		Provider: GetString("gcp"),
+	}
+	cloudAccount.Name = GetString("gcp-account")
+
+	if err := validateIdentityCloudAccount("gcp_access_policy", "gcp", cloudAccount); err != nil {
+		t.Errorf("A GCP cloud account should be valid for a GCP access policy, got: %s", err)
+	}
+
+	if err := validateIdentityCloudAccount("aws_access_policy", "aws", cloudAccount); err == nil {
+		t.Errorf("A GCP cloud account should not be valid for an AWS access policy")
+	}
+}
+
+func TestControlPlane_CloudAccountStatusWarning(t *testing.T) {
+
+	cloudAccount := &client.CloudAccount{
+		Status: &client.CloudAccountStatus{
+			Usable: GetBool(true),
+		},
+	}
+	cloudAccount.Name = GetString("aws-account")
+
+	if cloudAccountStatusWarning("aws_access_policy", cloudAccount) != nil {
+		t.Errorf("A usable cloud account should not produce a warning")
+	}
+
+	cloudAccount.Status.Usable = GetBool(false)
+	cloudAccount.Status.LastError = GetString("AccessDenied: unable to assume role")
+
+	warning := cloudAccountStatusWarning("aws_access_policy", cloudAccount)
+
+	if warning == nil || warning.Severity != diag.Warning {
+		t.Fatalf("An unusable cloud account should produce a warning")
+	}
+
+	expectedDetail := "Cloud account 'aws-account' referenced by 'aws_access_policy' is not usable by Control Plane. Last error: AccessDenied: unable to assume role"
+
+	if warning.Detail != expectedDetail {
+		t.Errorf("Unexpected warning detail, got: %s", warning.Detail)
+	}
+}
+
+func TestControlPlane_BuildAwsPolicyDocument(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceIdentity().Schema, map[string]interface{}{
+		"gvc":  "default",
+		"name": "identity",
+		"aws_access_policy": []interface{}{
+			map[string]interface{}{
+				"cloud_account_link": "/org/default/cloudaccount/aws",
+				"policy_refs":        []interface{}{"aws::ReadOnlyAccess"},
+				"trust_policy": []interface{}{
+					map[string]interface{}{
+						"statement": []interface{}{
+							map[string]interface{}{
+								"action": []interface{}{"sts:AssumeRole"},
+								"principal": []interface{}{
+									map[string]interface{}{
+										"type":        "Service",
+										"identifiers": []interface{}{"ec2.amazonaws.com"},
+									},
+								},
+								"condition": []interface{}{
+									map[string]interface{}{
+										"test":     "StringEquals",
+										"variable": "aws:SourceAccount",
+										"values":   []interface{}{"123456789012"},
+									},
+								},
+							},
+						},
+					},
+				},
+				"inline_policy_json": `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":["arn:aws:s3:::bucket/*"]}]}`,
+			},
+		},
+	})
+
+	identity := client.Identity{}
+	buildAwsIdentity(d.Get("aws_access_policy").([]interface{}), &identity, false)
+
+	expectedTrustPolicy := &client.AwsPolicyDocument{
+		Version: GetString("2012-10-17"),
+		Statement: &[]client.AwsPolicyStatement{
+			{
+				Effect: GetString("Allow"),
+				Principal: &client.AwsPolicyPrincipal{
+					"Service": {"ec2.amazonaws.com"},
+				},
+				Action: &client.AwsPolicyStringList{"sts:AssumeRole"},
+				Condition: &map[string]map[string]client.AwsPolicyConditionValues{
+					"StringEquals": {
+						"aws:SourceAccount": {"123456789012"},
+					},
+				},
+			},
+		},
+	}
+
+	expectedInlinePolicy := &client.AwsPolicyDocument{
+		Statement: &[]client.AwsPolicyStatement{
+			{
+				Effect:   GetString("Allow"),
+				Action:   &client.AwsPolicyStringList{"s3:GetObject"},
+				Resource: &client.AwsPolicyStringList{"arn:aws:s3:::bucket/*"},
+			},
+		},
+	}
+
+	if diff := deep.Equal(identity.Aws.TrustPolicy, expectedTrustPolicy); diff != nil {
+		t.Errorf("AWS trust policy was not built correctly. Diff: %s", diff)
+	}
+
+	if diff := deep.Equal(identity.Aws.InlinePolicy, expectedInlinePolicy); diff != nil {
+		t.Errorf("AWS inline policy was not built correctly. Diff: %s", diff)
+	}
+
+	flattened := flattenAwsIdentity(identity.Aws, getAwsPolicyJsonForms(d.Get("aws_access_policy").([]interface{})))[0].(map[string]interface{})
+
+	if _, ok := flattened["trust_policy"]; !ok {
+		t.Errorf("AWS trust policy defined as a block should be flattened into a block")
+	}
+
+	if !diffSuppressAwsPolicyDocument("", flattened["inline_policy_json"].(string), d.Get("aws_access_policy.0.inline_policy_json").(string), nil) {
+		t.Errorf("AWS inline policy defined as JSON should be flattened into an equivalent JSON document, got: %s", flattened["inline_policy_json"])
+	}
+}
+
+func TestControlPlane_DiffSuppressAwsPolicyDocument(t *testing.T) {
+
+	old := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject","s3:GetObject"],"Resource":"*"}]}`
+	new := `{
+		"Statement": [
+			{
+				"Resource": ["*"],
+				"Action": ["s3:GetObject", "s3:PutObject"],
+				"Effect": "Allow"
+			}
+		],
+		"Version": "2012-10-17"
+	}`
+
+	if !diffSuppressAwsPolicyDocument("", old, new, nil) {
+		t.Errorf("Equivalent AWS policy documents should not produce a diff")
+	}
+
+	changed := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":["s3:PutObject","s3:GetObject"],"Resource":"*"}]}`
+
+	if diffSuppressAwsPolicyDocument("", old, changed, nil) {
+		t.Errorf("Different AWS policy documents should produce a diff")
+	}
+
+	if _, errs := AwsPolicyDocumentValidator(`{"Version":"2012-10-17"}`, "inline_policy_json"); len(errs) == 0 {
+		t.Errorf("An AWS policy document without statements should be invalid")
+	}
+
+	if _, errs := AwsPolicyDocumentValidator(`{"Statement":`, "inline_policy_json"); len(errs) == 0 {
+		t.Errorf("A malformed AWS policy document should be invalid")
+	}
+
+	if _, errs := AwsPolicyDocumentValidator(`{"Statement":[{"Effect":"Allow","Actions":"s3:GetObject"}]}`, "inline_policy_json"); len(errs) == 0 {
+		t.Errorf("An AWS policy document with an unknown element should be invalid")
+	}
+
+	if _, errs := AwsPolicyDocumentValidator(`{"Statement":[{"Effect":"Allow","Action":"s3:*","NotAction":"s3:DeleteBucket"}]}`, "inline_policy_json"); len(errs) == 0 {
+		t.Errorf("An AWS policy statement with both Action and NotAction should be invalid")
+	}
+}
+
+func TestControlPlane_ParseAwsPolicyDocument(t *testing.T) {
+
+	document := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Principal": "*",
+				"NotAction": ["s3:DeleteBucket", "iam:*"],
+				"NotResource": "arn:aws:s3:::audit/*"
+			},
+			{
+				"Effect": "Deny",
+				"NotPrincipal": {"AWS": "arn:aws:iam::123456789012:root"},
+				"Action": "s3:*",
+				"Resource": "*"
+			}
+		]
+	}`
+
+	parsed, err := ParseAwsPolicyDocument(document)
+
+	if err != nil {
+		t.Fatalf("AWS policy document should be parsed, got: %s", err)
+	}
+
+	expected := &client.AwsPolicyDocument{
+		Version: GetString("2012-10-17"),
+		Statement: &[]client.AwsPolicyStatement{
+			{
+				Effect:      GetString("Allow"),
+				Principal:   &client.AwsPolicyPrincipal{"*": {"*"}},
+				NotAction:   &client.AwsPolicyStringList{"iam:*", "s3:DeleteBucket"},
+				NotResource: &client.AwsPolicyStringList{"arn:aws:s3:::audit/*"},
+			},
+			{
+				Effect:       GetString("Deny"),
+				NotPrincipal: &client.AwsPolicyPrincipal{"AWS": {"arn:aws:iam::123456789012:root"}},
+				Action:       &client.AwsPolicyStringList{"s3:*"},
+				Resource:     &client.AwsPolicyStringList{"*"},
+			},
+		},
+	}
+
+	if diff := deep.Equal(parsed, expected); diff != nil {
+		t.Errorf("AWS policy document was not parsed correctly. Diff: %s", diff)
+	}
+
+	// The anonymous principal is serialized back into its string form
+	data, _ := json.Marshal((*parsed.Statement)[0].Principal)
+
+	if string(data) != `"*"` {
+		t.Errorf("Anonymous principal was not serialized correctly, got: %s", data)
+	}
+
+	flattened := flattenAwsPolicyDocument(parsed)[0].(map[string]interface{})["statement"].([]interface{})
+
+	if diff := deep.Equal(flattened[0].(map[string]interface{})["principal"], []interface{}{
+		map[string]interface{}{"type": "*", "identifiers": []string{"*"}},
+	}); diff != nil {
+		t.Errorf("Anonymous principal was not flattened correctly. Diff: %s", diff)
+	}
+
+	if _, err := ParseAwsPolicyDocument(`{"Statement":[{"Effect":"Allow","Principal":"arn:aws:iam::123456789012:root"}]}`); err == nil {
+		t.Errorf("A principal given as a string other than '*' should be rejected")
+	}
+}
+
+func TestControlPlane_ParseAwsPolicyDocumentSingleStatement(t *testing.T) {
+
+	document := `{
+		"Version": "2012-10-17",
+		"Statement": {
+			"Effect": "Deny",
+			"Action": "s3:*",
+			"Resource": "*",
+			"Condition": {
+				"Bool": {"aws:SecureTransport": false},
+				"NumericLessThan": {"aws:MultiFactorAuthAge": 3600},
+				"StringEquals": {"aws:PrincipalTag/team": ["payments", "search"]},
+				"NumericEquals": {"s3:max-keys": [10, 2.5]}
+			}
+		}
+	}`
+
+	parsed, err := ParseAwsPolicyDocument(document)
+
+	if err != nil {
+		t.Fatalf("AWS policy document with a single statement should be parsed, got: %s", err)
+	}
+
+	expected := &client.AwsPolicyDocument{
+		Version: GetString("2012-10-17"),
+		Statement: &[]client.AwsPolicyStatement{
+			{
+				Effect:   GetString("Deny"),
+				Action:   &client.AwsPolicyStringList{"s3:*"},
+				Resource: &client.AwsPolicyStringList{"*"},
+				Condition: &map[string]map[string]client.AwsPolicyConditionValues{
+					"Bool": {
+						"aws:SecureTransport": {"false"},
+					},
+					"NumericLessThan": {
+						"aws:MultiFactorAuthAge": {"3600"},
+					},
+					"StringEquals": {
+						"aws:PrincipalTag/team": {"payments", "search"},
+					},
+					"NumericEquals": {
+						"s3:max-keys": {"10", "2.5"},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(parsed, expected); diff != nil {
+		t.Errorf("AWS policy document was not parsed correctly. Diff: %s", diff)
+	}
+
+	for _, invalid := range []string{
+		`{"Statement":{"Effect":"Allow","Actoin":"s3:*"}}`,
+		`{"Statement":[{"Effect":"Allow","Condition":{"Bool":{"aws:SecureTransport":{"value":false}}}}]}`,
+		`{"Statement":[{"Effect":"Allow","Condition":{"Bool":{"aws:SecureTransport":[[false]]}}}]}`,
+	} {
+		if _, err := ParseAwsPolicyDocument(invalid); err == nil {
+			t.Errorf("AWS policy document should be rejected: %s", invalid)
+		}
+	}
+}
+
+func TestControlPlane_MergeNetworkResources(t *testing.T) {
+
+	declared := &[]client.NetworkResource{
+		{Name: GetString("app-db"), AgentLink: GetString("/org/example/agent/updated")},
+	}
+
+	current := &[]client.NetworkResource{
+		{Name: GetString("app-db"), AgentLink: GetString("/org/example/agent/original")},
+		{Name: GetString("console-added"), AgentLink: GetString("/org/example/agent/original")},
+		{Name: GetString("team-cache"), AgentLink: GetString("/org/example/agent/team")},
+	}
+
+	tags := &map[string]interface{}{
+		"team": "platform",
+		client.IdentityNetworkResourceOwnerTagPrefix + "team-cache":          "true",
+		client.IdentityNativeNetworkResourceOwnerTagPrefix + "console-added": "true",
+	}
+
+	owned := getOwnedNetworkResourceNames(tags, client.IdentityNetworkResourceOwnerTagPrefix)
+
+	if diff := deep.Equal(owned, map[string]bool{"team-cache": true}); diff != nil {
+		t.Errorf("Owned network resource names were not collected correctly. Diff: %s", diff)
+	}
+
+	// Only the entries owned by the standalone resources survive next to the declared ones
+	merged := mergeNetworkResources(declared, current, owned)
+	expectedMerged := &[]client.NetworkResource{
+		{Name: GetString("app-db"), AgentLink: GetString("/org/example/agent/updated")},
+		{Name: GetString("team-cache"), AgentLink: GetString("/org/example/agent/team")},
+	}
+
+	if diff := deep.Equal(merged, expectedMerged); diff != nil {
+		t.Errorf("Network resources were not merged correctly. Diff: %s", diff)
+	}
+
+	// Entries added outside of Terraform are kept in state so they show up as drift
+	filtered := filterNetworkResources(current, owned)
+	expectedFiltered := &[]client.NetworkResource{
+		{Name: GetString("app-db"), AgentLink: GetString("/org/example/agent/original")},
+		{Name: GetString("console-added"), AgentLink: GetString("/org/example/agent/original")},
+	}
+
+	if diff := deep.Equal(filtered, expectedFiltered); diff != nil {
+		t.Errorf("Network resources were not filtered correctly. Diff: %s", diff)
+	}
+
+	// Without owner tags, as after an import, everything is flattened
+	if diff := deep.Equal(filterNetworkResources(current, getOwnedNetworkResourceNames(nil, client.IdentityNetworkResourceOwnerTagPrefix)), current); diff != nil {
+		t.Errorf("Network resources without owner tags were filtered. Diff: %s", diff)
+	}
+
+	if diff := deep.Equal(getIdentityTags(tags), map[string]interface{}{"team": "platform"}); diff != nil {
+		t.Errorf("Owner tags should not be exposed in the identity tags. Diff: %s", diff)
+	}
+
+	// Only the identity hides the owner tags, other resources keep every tag
+	if _, ok := GetTags(tags)[client.IdentityNetworkResourceOwnerTagPrefix+"team-cache"]; !ok {
+		t.Errorf("Tags of other resources should not be filtered")
+	}
+}
+
+func TestControlPlane_MergeNativeNetworkResources(t *testing.T) {
+
+	_, aws, _ := generateNativeNetworkResource_WithAWS()
+	_, gcp, _ := generateNativeNetworkResource_WithGCP()
+	gcp.Name = GetString("test-gcp")
+
+	owned := map[string]bool{*gcp.Name: true}
+
+	merged := mergeNativeNetworkResources(&[]client.NativeNetworkResource{aws}, &[]client.NativeNetworkResource{aws, gcp}, owned)
+	expectedMerged := &[]client.NativeNetworkResource{aws, gcp}
+
+	if diff := deep.Equal(merged, expectedMerged); diff != nil {
+		t.Errorf("Native network resources were not merged correctly. Diff: %s", diff)
+	}
+
+	// An entry removed from the identity is dropped unless a standalone resource owns it
+	merged = mergeNativeNetworkResources(&[]client.NativeNetworkResource{}, &[]client.NativeNetworkResource{aws, gcp}, owned)
+	expectedMerged = &[]client.NativeNetworkResource{gcp}
+
+	if diff := deep.Equal(merged, expectedMerged); diff != nil {
+		t.Errorf("Native network resources were not merged correctly after a removal. Diff: %s", diff)
+	}
+
+	filtered := filterNativeNetworkResources(&[]client.NativeNetworkResource{aws, gcp}, owned)
+	expectedFiltered := &[]client.NativeNetworkResource{aws}
+
+	if diff := deep.Equal(filtered, expectedFiltered); diff != nil {
+		t.Errorf("Native network resources were not filtered correctly. Diff: %s", diff)
+	}
+}
+
 /*** Generate ***/
 func generateNgsIdentity_WithCloudAccountLink() (*client.NgsIdentity, client.NgsIdentity, []interface{}) {
 
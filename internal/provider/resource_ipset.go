@@ -0,0 +1,371 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Main ***/
+func resourceIpSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIpSetCreate,
+		ReadContext:   resourceIpSetRead,
+		UpdateContext: resourceIpSetUpdate,
+		DeleteContext: resourceIpSetDelete,
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     DescriptionValidator,
+				DiffSuppressFunc: DiffSuppressDescription,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: TagValidator,
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"link": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(string)
+
+					if !strings.HasPrefix(v, "/org/") || !strings.Contains(v, "/gvc/") || !strings.Contains(v, "/workload/") {
+						errs = append(errs, fmt.Errorf("%q must be a full workload link (/org/ORG_NAME/gvc/GVC_NAME/workload/WORKLOAD_NAME), got: %s", key, v))
+					}
+
+					return
+				},
+			},
+			"location": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"retention_policy": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "keep",
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+								v := val.(string)
+
+								if v != "keep" && v != "free" {
+									errs = append(errs, fmt.Errorf("%q must be either 'keep' or 'free', got: %s", key, v))
+								}
+
+								return
+							},
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ip": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"state": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"created": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"warning": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{},
+	}
+}
+
+func setIpSet(d *schema.ResourceData, ipSet *client.IpSet, org string) diag.Diagnostics {
+
+	if ipSet == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(*ipSet.Name)
+
+	if err := SetBase(d, ipSet.Base); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := SetSelfLink(ipSet.Links, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", flattenIpSetStatus(ipSet.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if ipSet.Spec == nil {
+		return nil
+	}
+
+	if err := d.Set("link", ipSet.Spec.Link); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("location", flattenIpSetLocations(org, ipSet.Spec.Locations)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceIpSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+
+	ipSet := client.IpSet{}
+	ipSet.Name = GetString(d.Get("name"))
+	ipSet.Description = GetDescriptionString(d.Get("description"), *ipSet.Name)
+	ipSet.Tags = GetStringMap(d.Get("tags"))
+	ipSet.Spec = &client.IpSetSpec{
+		Link:      GetString(d.Get("link")),
+		Locations: buildIpSetLocations(c.Org, d.Get("location").(*schema.Set).List()),
+	}
+
+	newIpSet, code, err := c.CreateIpSet(ipSet)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setIpSet(d, newIpSet, c.Org)
+}
+
+func resourceIpSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	ipSet, code, err := c.GetIpSet(d.Id())
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setIpSet(d, ipSet, c.Org)
+}
+
+func resourceIpSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("description", "tags", "link", "location") {
+
+		c := m.(*client.Client)
+
+		ipSetToUpdate := client.IpSet{}
+		ipSetToUpdate.Name = GetString(d.Get("name"))
+
+		if d.HasChange("description") {
+			ipSetToUpdate.Description = GetDescriptionString(d.Get("description"), *ipSetToUpdate.Name)
+		}
+
+		if d.HasChange("tags") {
+			ipSetToUpdate.Tags = GetTagChanges(d)
+		}
+
+		if d.HasChanges("link", "location") {
+			ipSetToUpdate.SpecReplace = &client.IpSetSpec{
+				Link:      GetString(d.Get("link")),
+				Locations: buildIpSetLocations(c.Org, d.Get("location").(*schema.Set).List()),
+			}
+		}
+
+		updatedIpSet, _, err := c.UpdateIpSet(ipSetToUpdate)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setIpSet(d, updatedIpSet, c.Org)
+	}
+
+	return nil
+}
+
+func resourceIpSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	err := c.DeleteIpSet(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+/*** Build ***/
+func buildIpSetLocations(org string, specs []interface{}) *[]client.IpSetLocation {
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	output := []client.IpSetLocation{}
+
+	for _, item := range specs {
+
+		spec := item.(map[string]interface{})
+
+		output = append(output, client.IpSetLocation{
+			Name:            GetString(fmt.Sprintf("/org/%s/location/%s", org, spec["name"].(string))),
+			RetentionPolicy: GetString(spec["retention_policy"]),
+		})
+	}
+
+	return &output
+}
+
+/*** Flatten ***/
+func flattenIpSetLocations(org string, locations *[]client.IpSetLocation) []interface{} {
+
+	if locations == nil || len(*locations) == 0 {
+		return nil
+	}
+
+	output := make([]interface{}, len(*locations))
+
+	for i, location := range *locations {
+
+		spec := map[string]interface{}{}
+
+		if location.Name != nil {
+			spec["name"] = strings.TrimPrefix(*location.Name, fmt.Sprintf("/org/%s/location/", org))
+		}
+
+		if location.RetentionPolicy != nil {
+			spec["retention_policy"] = *location.RetentionPolicy
+		}
+
+		output[i] = spec
+	}
+
+	return output
+}
+
+func flattenIpSetStatus(status *client.IpSetStatus) []interface{} {
+
+	if status == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if status.IpAddresses != nil {
+
+		ipAddresses := make([]interface{}, len(*status.IpAddresses))
+
+		for i, ipAddress := range *status.IpAddresses {
+
+			flattened := map[string]interface{}{}
+
+			if ipAddress.Name != nil {
+				flattened["name"] = *ipAddress.Name
+			}
+
+			if ipAddress.Ip != nil {
+				flattened["ip"] = *ipAddress.Ip
+			}
+
+			if ipAddress.Id != nil {
+				flattened["id"] = *ipAddress.Id
+			}
+
+			if ipAddress.State != nil {
+				flattened["state"] = *ipAddress.State
+			}
+
+			if ipAddress.Created != nil {
+				flattened["created"] = *ipAddress.Created
+			}
+
+			ipAddresses[i] = flattened
+		}
+
+		spec["ip_address"] = ipAddresses
+	}
+
+	if status.Error != nil {
+		spec["error"] = *status.Error
+	}
+
+	if status.Warning != nil {
+		spec["warning"] = *status.Warning
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
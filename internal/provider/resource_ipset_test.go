@@ -0,0 +1,255 @@
+package cpln
+
+import (
+	"fmt"
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+/*** Acc Provider Tests ***/
+
+func TestAccControlPlaneIpSet_basic(t *testing.T) {
+
+	var ipSet client.IpSet
+
+	random := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	name := "ipset-" + random
+	gvcName := "gvc-ipset-" + random
+	workloadName := "workload-ipset-" + random
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t, "IPSET") },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckControlPlaneIpSetCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlPlaneIpSet(gvcName, workloadName, name, "keep"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneIpSetExists("cpln_ipset.new", name, &ipSet),
+					resource.TestCheckResourceAttr("cpln_ipset.new", "location.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("cpln_ipset.new", "location.*", map[string]string{
+						"name":             "aws-eu-central-1",
+						"retention_policy": "keep",
+					}),
+				),
+			},
+			{
+				Config: testAccControlPlaneIpSet(gvcName, workloadName, name, "free"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneIpSetExists("cpln_ipset.new", name, &ipSet),
+					resource.TestCheckTypeSetElemNestedAttrs("cpln_ipset.new", "location.*", map[string]string{
+						"name":             "aws-eu-central-1",
+						"retention_policy": "free",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlPlaneIpSet(gvcName, workloadName, name, retentionPolicy string) string {
+
+	TestLogger.Printf("Inside testAccControlPlaneIpSet")
+
+	return fmt.Sprintf(`
+
+	resource "cpln_gvc" "new" {
+		name      = "%s"
+		locations = ["aws-eu-central-1"]
+	}
+
+	resource "cpln_workload" "new" {
+		gvc  = cpln_gvc.new.name
+		name = "%s"
+		type = "standard"
+
+		container {
+			name   = "container-01"
+			image  = "gcr.io/knative-samples/helloworld-go"
+			memory = "128Mi"
+			cpu    = "50m"
+
+			ports {
+				protocol = "http"
+				number   = "8080"
+			}
+		}
+
+		options {
+			capacity_ai     = false
+			timeout_seconds = 5
+
+			autoscaling {
+				metric          = "cpu"
+				target          = 60
+				max_scale       = 1
+				min_scale       = 1
+				max_concurrency = 0
+			}
+		}
+
+		firewall_spec {
+			external {
+				inbound_allow_cidr  = ["0.0.0.0/0"]
+				outbound_allow_cidr = []
+			}
+		}
+	}
+
+	resource "cpln_ipset" "new" {
+		name        = "%s"
+		description = "IP set created using terraform for acceptance tests"
+		link        = cpln_workload.new.self_link
+
+		location {
+			name             = "aws-eu-central-1"
+			retention_policy = "%s"
+		}
+	}
+	`, gvcName, workloadName, name, retentionPolicy)
+}
+
+func testAccCheckControlPlaneIpSetExists(resourceName, ipSetName string, ipSet *client.IpSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		TestLogger.Printf("Inside testAccCheckControlPlaneIpSetExists. Resources Length: %d", len(s.RootModule().Resources))
+
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", s)
+		}
+
+		if rs.Primary.ID != ipSetName {
+			return fmt.Errorf("IP set name does not match")
+		}
+
+		client := testAccProvider.Meta().(*client.Client)
+
+		i, _, err := client.GetIpSet(ipSetName)
+
+		if err != nil {
+			return err
+		}
+
+		if *i.Name != ipSetName {
+			return fmt.Errorf("IP set name does not match")
+		}
+
+		*ipSet = *i
+
+		return nil
+	}
+}
+
+func testAccCheckControlPlaneIpSetCheckDestroy(s *terraform.State) error {
+
+	if len(s.RootModule().Resources) == 0 {
+		return fmt.Errorf("Error In CheckDestroy. No Resources To Verify")
+	}
+
+	c := testAccProvider.Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "cpln_ipset" {
+			continue
+		}
+
+		ipSetName := rs.Primary.ID
+
+		ipSet, _, _ := c.GetIpSet(ipSetName)
+		if ipSet != nil {
+			return fmt.Errorf("IP set still exists. Name: %s", *ipSet.Name)
+		}
+	}
+
+	return nil
+}
+
+/*** Unit Tests ***/
+// Build //
+func TestControlPlane_BuildIpSetLocations(t *testing.T) {
+
+	locations, expectedLocations, _ := generateTestIpSetLocations()
+
+	if diff := deep.Equal(locations, expectedLocations); diff != nil {
+		t.Errorf("IP Set Locations were not built correctly, Diff: %s", diff)
+	}
+}
+
+// Flatten //
+func TestControlPlane_FlattenIpSetLocations(t *testing.T) {
+
+	_, expectedLocations, expectedFlatten := generateTestIpSetLocations()
+	flattenedLocations := flattenIpSetLocations("unit-test-org", expectedLocations)
+
+	if diff := deep.Equal(expectedFlatten, flattenedLocations); diff != nil {
+		t.Errorf("IP Set Locations were not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenIpSetStatus(t *testing.T) {
+
+	name := "ip-01"
+	ip := "3.120.10.10"
+	state := "bound"
+
+	status := &client.IpSetStatus{
+		IpAddresses: &[]client.IpSetIpAddress{
+			{
+				Name:  &name,
+				Ip:    &ip,
+				State: &state,
+			},
+		},
+	}
+
+	expectedFlatten := []interface{}{
+		map[string]interface{}{
+			"ip_address": []interface{}{
+				map[string]interface{}{
+					"name":  name,
+					"ip":    ip,
+					"state": state,
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(expectedFlatten, flattenIpSetStatus(status)); diff != nil {
+		t.Errorf("IP Set Status was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+/*** Generate ***/
+func generateTestIpSetLocations() (*[]client.IpSetLocation, *[]client.IpSetLocation, []interface{}) {
+
+	org := "unit-test-org"
+	locationName := "aws-eu-central-1"
+	locationLink := fmt.Sprintf("/org/%s/location/%s", org, locationName)
+	retentionPolicy := "free"
+
+	flattened := []interface{}{
+		map[string]interface{}{
+			"name":             locationName,
+			"retention_policy": retentionPolicy,
+		},
+	}
+
+	locations := buildIpSetLocations(org, flattened)
+	expectedLocations := []client.IpSetLocation{
+		{
+			Name:            &locationLink,
+			RetentionPolicy: &retentionPolicy,
+		},
+	}
+
+	return locations, &expectedLocations, flattened
+}
@@ -0,0 +1,294 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceLocation() *schema.Resource {
+
+	return &schema.Resource{
+		CreateContext: resourceLocationCreate,
+		ReadContext:   resourceLocationRead,
+		UpdateContext: resourceLocationUpdate,
+		DeleteContext: resourceLocationDelete,
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     DescriptionValidator,
+				DiffSuppressFunc: DiffSuppressDescription,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: TagValidator,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"cloud_provider": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"geo": client.GeoSchema(),
+			"ip_ranges": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"original_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"original_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"original_tags": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateLocation,
+		},
+	}
+}
+
+func importStateLocation(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	c := meta.(*client.Client)
+	location, _, err := c.GetLocation(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if e := setLocationOriginalState(d, location); e.HasError() {
+		return nil, fmt.Errorf("unable to record the original state of location '%s'", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceLocationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+
+	locationName := d.Get("name").(string)
+
+	// Locations cannot be created, only existing locations can be claimed
+	existingLocation, code, err := c.GetLocation(locationName)
+
+	if code == 404 {
+		return diag.FromErr(fmt.Errorf("location '%s' does not exist. Only existing locations can be managed", locationName))
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The original state is restored when the location is released
+	if e := setLocationOriginalState(d, existingLocation); e != nil {
+		return e
+	}
+
+	location := client.Location{}
+	location.Name = GetString(locationName)
+	location.Description = GetDescriptionString(d.Get("description"), locationName)
+	location.Tags = buildLocationClaimTags(existingLocation.Tags, d.Get("tags").(map[string]interface{}))
+	location.Spec = &client.LocationSpec{
+		Enabled: GetBool(d.Get("enabled")),
+	}
+
+	updatedLocation, _, err := c.UpdateLocation(location)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setLocation(d, updatedLocation)
+}
+
+func resourceLocationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	location, code, err := c.GetLocation(d.Id())
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setLocation(d, location)
+}
+
+func resourceLocationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("description", "tags", "enabled") {
+
+		locationToUpdate := client.Location{}
+		locationToUpdate.Name = GetString(d.Get("name"))
+
+		if d.HasChange("description") {
+			locationToUpdate.Description = GetDescriptionString(d.Get("description"), *locationToUpdate.Name)
+		}
+
+		if d.HasChange("tags") {
+			locationToUpdate.Tags = GetTagChanges(d)
+		}
+
+		if d.HasChange("enabled") {
+			locationToUpdate.Spec = &client.LocationSpec{
+				Enabled: GetBool(d.Get("enabled")),
+			}
+		}
+
+		c := m.(*client.Client)
+		updatedLocation, _, err := c.UpdateLocation(locationToUpdate)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setLocation(d, updatedLocation)
+	}
+
+	return nil
+}
+
+func resourceLocationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Locations cannot be deleted. Release the location by restoring its original state and tags
+	rawState := d.GetRawState()
+
+	// Locations claimed before the original state was recorded are re-enabled
+	enabled := true
+
+	if !rawState.IsNull() && !rawState.GetAttr("original_enabled").IsNull() {
+		enabled = d.Get("original_enabled").(bool)
+	}
+
+	location := client.Location{}
+	location.Name = GetString(d.Id())
+	location.Tags = buildLocationReleaseTags(d.Get("tags").(map[string]interface{}), d.Get("original_tags").(map[string]interface{}))
+	location.Spec = &client.LocationSpec{
+		Enabled: GetBool(enabled),
+	}
+
+	// An empty original description is restored as well, only states without the recorded value are left alone
+	if !rawState.IsNull() && !rawState.GetAttr("original_description").IsNull() {
+		location.Description = GetString(d.Get("original_description").(string))
+	}
+
+	c := m.(*client.Client)
+	_, code, err := c.UpdateLocation(location)
+
+	if err != nil && code != 404 {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setLocationOriginalState(d *schema.ResourceData, location *client.Location) diag.Diagnostics {
+
+	enabled := true
+
+	if location.Spec != nil && location.Spec.Enabled != nil {
+		enabled = *location.Spec.Enabled
+	}
+
+	if err := d.Set("original_enabled", enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	description := ""
+
+	if location.Description != nil {
+		description = *location.Description
+	}
+
+	if err := d.Set("original_description", description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("original_tags", GetTags(location.Tags)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+/*** Build ***/
+
+// buildLocationClaimTags - Tags already on the location that are not configured are dropped, so the configured tags are the only user tags
+func buildLocationClaimTags(existingTags *map[string]interface{}, configuredTags map[string]interface{}) *map[string]interface{} {
+
+	tags := map[string]interface{}{}
+
+	// GetTags leaves out the tags generated by Control Plane, which are kept
+	for k := range GetTags(existingTags) {
+		tags[k] = nil
+	}
+
+	for k, v := range configuredTags {
+		tags[k] = v
+	}
+
+	return &tags
+}
+
+// buildLocationReleaseTags - Removes the managed tags and puts back the tags the location had before it was claimed
+func buildLocationReleaseTags(managedTags map[string]interface{}, originalTags map[string]interface{}) *map[string]interface{} {
+
+	tags := map[string]interface{}{}
+
+	for k := range managedTags {
+		tags[k] = nil
+	}
+
+	for k, v := range originalTags {
+		tags[k] = v
+	}
+
+	return &tags
+}
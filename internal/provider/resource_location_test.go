@@ -0,0 +1,206 @@
+package cpln
+
+import (
+	"fmt"
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccControlPlaneLocation_basic(t *testing.T) {
+
+	var testLocation client.Location
+
+	locationName := "aws-eu-central-1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t, "LOCATION") },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckControlPlaneLocationCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlPlaneLocation(locationName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneLocationExists("cpln_location.new", locationName, &testLocation),
+					resource.TestCheckResourceAttr("cpln_location.new", "enabled", "false"),
+					resource.TestCheckResourceAttr("cpln_location.new", "tags.terraform_generated", "true"),
+					resource.TestCheckResourceAttr("cpln_location.new", "cloud_provider", "aws"),
+				),
+			},
+			{
+				Config: testAccControlPlaneLocation(locationName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneLocationExists("cpln_location.new", locationName, &testLocation),
+					resource.TestCheckResourceAttr("cpln_location.new", "enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlPlaneLocation(locationName string, enabled bool) string {
+
+	TestLogger.Printf("Inside testAccControlPlaneLocation")
+
+	return fmt.Sprintf(`
+
+	resource "cpln_location" "new" {
+		name    = "%s"
+		enabled = %t
+
+		tags = {
+		  terraform_generated = "true"
+		  acceptance_test = "true"
+		}
+	}
+	`, locationName, enabled)
+}
+
+func testAccCheckControlPlaneLocationExists(resourceName, locationName string, location *client.Location) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		TestLogger.Printf("Inside testAccCheckControlPlaneLocationExists. Resources Length: %d", len(s.RootModule().Resources))
+
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", s)
+		}
+
+		if rs.Primary.ID != locationName {
+			return fmt.Errorf("Location name does not match")
+		}
+
+		client := testAccProvider.Meta().(*client.Client)
+
+		l, _, err := client.GetLocation(locationName)
+
+		if err != nil {
+			return err
+		}
+
+		if *l.Name != locationName {
+			return fmt.Errorf("Location name does not match")
+		}
+
+		*location = *l
+
+		return nil
+	}
+}
+
+func testAccCheckControlPlaneLocationCheckDestroy(s *terraform.State) error {
+
+	c := testAccProvider.Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "cpln_location" {
+			continue
+		}
+
+		locationName := rs.Primary.ID
+
+		// Locations are released, not deleted
+		location, _, err := c.GetLocation(locationName)
+		if err != nil {
+			return err
+		}
+
+		if location.Spec == nil || location.Spec.Enabled == nil || !*location.Spec.Enabled {
+			return fmt.Errorf("Location was not re-enabled on release. Name: %s", locationName)
+		}
+
+		if location.Tags != nil {
+			if _, ok := (*location.Tags)["terraform_generated"]; ok {
+				return fmt.Errorf("Location tags were not removed on release. Name: %s", locationName)
+			}
+		}
+	}
+
+	return nil
+}
+
+/*** Unit Tests ***/
+func TestControlPlane_BuildLocationClaimTags(t *testing.T) {
+
+	existingTags := &map[string]interface{}{
+		"owner":                "platform",
+		"region-group":         "eu",
+		"cpln/deployTimestamp": "2024-05-01T10:00:00.000Z",
+	}
+
+	tags := buildLocationClaimTags(existingTags, map[string]interface{}{
+		"owner":               "networking",
+		"terraform_generated": "true",
+	})
+
+	expectedTags := &map[string]interface{}{
+		"owner":               "networking",
+		"region-group":        nil,
+		"terraform_generated": "true",
+	}
+
+	if diff := deep.Equal(tags, expectedTags); diff != nil {
+		t.Errorf("Location claim tags were not built correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_BuildLocationReleaseTags(t *testing.T) {
+
+	tags := buildLocationReleaseTags(map[string]interface{}{
+		"owner":               "networking",
+		"terraform_generated": "true",
+	}, map[string]interface{}{
+		"owner":        "platform",
+		"region-group": "eu",
+	})
+
+	expectedTags := &map[string]interface{}{
+		"owner":               "platform",
+		"region-group":        "eu",
+		"terraform_generated": nil,
+	}
+
+	if diff := deep.Equal(tags, expectedTags); diff != nil {
+		t.Errorf("Location release tags were not built correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_SetLocationOriginalState(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceLocation().Schema, map[string]interface{}{
+		"name": "aws-eu-central-1",
+	})
+
+	location := &client.Location{}
+	location.Tags = &map[string]interface{}{
+		"owner":                "platform",
+		"cpln/deployTimestamp": "2024-05-01T10:00:00.000Z",
+	}
+	location.Spec = &client.LocationSpec{
+		Enabled: GetBool(false),
+	}
+
+	if e := setLocationOriginalState(d, location); e != nil {
+		t.Fatalf("Unexpected error: %v", e)
+	}
+
+	if d.Get("original_enabled").(bool) {
+		t.Errorf("The original enabled flag was not recorded")
+	}
+
+	// A location without a description is recorded with an empty one, so it is restored empty
+	if _, ok := d.GetOk("original_description"); ok {
+		t.Errorf("An empty original description should be recorded as empty")
+	}
+
+	if diff := deep.Equal(d.Get("original_tags"), map[string]interface{}{"owner": "platform"}); diff != nil {
+		t.Errorf("The original tags were not recorded correctly. Diff: %s", diff)
+	}
+}
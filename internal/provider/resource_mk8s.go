@@ -0,0 +1,1487 @@
+package cpln
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var mk8sProvidersNames = []string{
+	"generic_provider", "hetzner_provider", "aws_provider",
+}
+
+/*** Main ***/
+func resourceMk8s() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMk8sCreate,
+		ReadContext:   resourceMk8sRead,
+		UpdateContext: resourceMk8sUpdate,
+		DeleteContext: resourceMk8sDelete,
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     DescriptionValidator,
+				DiffSuppressFunc: DiffSuppressDescription,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: TagValidator,
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"firewall": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_cidr": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"generic_provider": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: mk8sProvidersNames,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"location": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"networking": mk8sNetworkingSchema(),
+						"node_pool":  mk8sNodePoolSchema(map[string]*schema.Schema{}),
+					},
+				},
+			},
+			"hetzner_provider": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: mk8sProvidersNames,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"hetzner_labels": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"networking": mk8sNetworkingSchema(),
+						"pre_install_script": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"token_secret_link": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: LinkValidator,
+						},
+						"network_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"node_pool": mk8sNodePoolSchema(map[string]*schema.Schema{
+							"server_type": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"override_image": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"min_size": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  0,
+							},
+							"max_size": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  0,
+							},
+						}),
+						"image": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"autoscaler": mk8sAutoscalerSchema(),
+					},
+				},
+			},
+			"aws_provider": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: mk8sProvidersNames,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"aws_tags": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"skip_create_roles": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"networking": mk8sNetworkingSchema(),
+						"pre_install_script": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"image": mk8sAwsAmiSchema(true),
+						"deploy_role_arn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: AwsRoleArnValidator,
+						},
+						"vpc_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key_pair": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"disk_encryption_key_arn": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"security_group_ids": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"node_pool": mk8sNodePoolSchema(map[string]*schema.Schema{
+							"instance_types": {
+								Type:     schema.TypeList,
+								Required: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+							"override_image": mk8sAwsAmiSchema(false),
+							"boot_disk_size": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  20,
+							},
+							"min_size": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  0,
+							},
+							"max_size": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  0,
+							},
+							"on_demand_base_capacity": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  0,
+							},
+							"on_demand_percentage_above_base_capacity": {
+								Type:     schema.TypeInt,
+								Optional: true,
+								Default:  0,
+								ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+									v := val.(int)
+									if v < 0 || v > 100 {
+										errs = append(errs, fmt.Errorf("%q must be between 0 and 100 inclusive, got: %d", key, v))
+									}
+									return
+								},
+							},
+							"spot_allocation_strategy": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								Default:      "lowest-price",
+								ValidateFunc: mk8sOneOfValidator("lowest-price", "capacity-optimized", "capacity-optimized-prioritized", "price-capacity-optimized"),
+							},
+							"subnet_ids": {
+								Type:     schema.TypeList,
+								Required: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+							"extra_security_group_ids": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						}),
+						"autoscaler": mk8sAutoscalerSchema(),
+					},
+				},
+			},
+			"add_ons": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dashboard": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"logs": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"audit_enabled": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"include_namespaces": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"exclude_namespaces": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"metrics": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"kube_state": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"core_dns": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"kubelet": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"api_server": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"node_exporter": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"cadvisor": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"oidc_provider_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"server_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"home_location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"add_ons": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"dashboard_url": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"loki_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"prometheus_endpoint": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"remote_write_config": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"health": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"status": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"message": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+												"last_checked": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{},
+	}
+}
+
+func mk8sNetworkingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"service_network": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"pod_network": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// mk8sNodePoolSchema - Node pool attributes shared by all providers, extended with the provider specific ones
+func mk8sNodePoolSchema(extra map[string]*schema.Schema) *schema.Schema {
+
+	nodePool := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: NameValidator,
+		},
+		"labels": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"taint": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"effect": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: mk8sOneOfValidator("NoSchedule", "PreferNoSchedule", "NoExecute"),
+					},
+				},
+			},
+		},
+	}
+
+	for key, value := range extra {
+		nodePool[key] = value
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: nodePool,
+		},
+	}
+}
+
+func mk8sAwsAmiSchema(computed bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: computed,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"recommended": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"exact": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func mk8sAutoscalerSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"expander": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: mk8sOneOfValidator("random", "most-pods", "least-waste", "price", "priority"),
+					},
+				},
+				"unneeded_time": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "10m",
+				},
+				"unready_time": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "20m",
+				},
+				"utilization_threshold": {
+					Type:     schema.TypeFloat,
+					Optional: true,
+					Default:  0.7,
+					ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+						v := val.(float64)
+						if v < 0.1 || v > 1 {
+							errs = append(errs, fmt.Errorf("%q must be between 0.1 and 1 inclusive, got: %f", key, v))
+						}
+						return
+					},
+				},
+			},
+		},
+	}
+}
+
+func mk8sOneOfValidator(values ...string) schema.SchemaValidateFunc {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+
+		value := val.(string)
+
+		for _, v := range values {
+			if v == value {
+				return
+			}
+		}
+
+		errs = append(errs, fmt.Errorf("%q must be one of %v, got: %s", key, values, value))
+
+		return
+	}
+}
+
+func setMk8s(d *schema.ResourceData, mk8s *client.Mk8s) diag.Diagnostics {
+
+	if mk8s == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(*mk8s.Name)
+
+	if err := SetBase(d, mk8s.Base); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := SetSelfLink(mk8s.Links, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("status", flattenMk8sStatus(mk8s.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if mk8s.Spec == nil {
+		return nil
+	}
+
+	if err := d.Set("version", mk8s.Spec.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("firewall", flattenMk8sFirewall(mk8s.Spec.Firewall)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if mk8s.Spec.Provider != nil {
+
+		if err := d.Set("generic_provider", flattenMk8sGenericProvider(mk8s.Spec.Provider.Generic)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("hetzner_provider", flattenMk8sHetznerProvider(mk8s.Spec.Provider.Hetzner)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("aws_provider", flattenMk8sAwsProvider(mk8s.Spec.Provider.Aws)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("add_ons", flattenMk8sAddOns(mk8s.Spec.AddOns)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMk8sCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	mk8s := client.Mk8s{}
+	mk8s.Name = GetString(d.Get("name"))
+	mk8s.Description = GetDescriptionString(d.Get("description"), *mk8s.Name)
+	mk8s.Tags = GetStringMap(d.Get("tags"))
+	mk8s.Spec = buildMk8sSpec(d)
+
+	c := m.(*client.Client)
+	newMk8s, code, err := c.CreateMk8s(mk8s)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setMk8s(d, newMk8s)
+}
+
+func resourceMk8sRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	mk8s, code, err := c.GetMk8s(d.Id())
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setMk8s(d, mk8s)
+}
+
+func resourceMk8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("description", "tags", "version", "firewall", "generic_provider", "hetzner_provider", "aws_provider", "add_ons") {
+
+		mk8sToUpdate := client.Mk8s{}
+		mk8sToUpdate.Name = GetString(d.Get("name"))
+
+		if d.HasChange("description") {
+			mk8sToUpdate.Description = GetDescriptionString(d.Get("description"), *mk8sToUpdate.Name)
+		}
+
+		if d.HasChange("tags") {
+			mk8sToUpdate.Tags = GetTagChanges(d)
+		}
+
+		if d.HasChanges("version", "firewall", "generic_provider", "hetzner_provider", "aws_provider", "add_ons") {
+			mk8sToUpdate.SpecReplace = buildMk8sSpec(d)
+		}
+
+		c := m.(*client.Client)
+		updatedMk8s, _, err := c.UpdateMk8s(mk8sToUpdate)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setMk8s(d, updatedMk8s)
+	}
+
+	return nil
+}
+
+func resourceMk8sDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	err := c.DeleteMk8s(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+/*** Build ***/
+func buildMk8sSpec(d *schema.ResourceData) *client.Mk8sSpec {
+
+	return &client.Mk8sSpec{
+		Version:  GetString(d.Get("version")),
+		Firewall: buildMk8sFirewall(d.Get("firewall").([]interface{})),
+		Provider: &client.Mk8sProvider{
+			Generic: buildMk8sGenericProvider(d.Get("generic_provider").([]interface{})),
+			Hetzner: buildMk8sHetznerProvider(d.Get("hetzner_provider").([]interface{})),
+			Aws:     buildMk8sAwsProvider(d.Get("aws_provider").([]interface{})),
+		},
+		AddOns: buildMk8sAddOns(d.Get("add_ons").([]interface{})),
+	}
+}
+
+func buildMk8sFirewall(specs []interface{}) *[]client.Mk8sFirewall {
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	output := []client.Mk8sFirewall{}
+
+	for _, item := range specs {
+
+		spec := item.(map[string]interface{})
+
+		output = append(output, client.Mk8sFirewall{
+			SourceCIDR:  GetString(spec["source_cidr"]),
+			Description: GetString(spec["description"]),
+		})
+	}
+
+	return &output
+}
+
+func buildMk8sGenericProvider(specs []interface{}) *client.Mk8sGenericProvider {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.Mk8sGenericProvider{
+		Location:   GetString(spec["location"]),
+		Networking: buildMk8sNetworking(spec["networking"].([]interface{})),
+	}
+
+	nodePools := []client.Mk8sNodePool{}
+
+	for _, item := range spec["node_pool"].([]interface{}) {
+		nodePools = append(nodePools, buildMk8sNodePool(item.(map[string]interface{})))
+	}
+
+	output.NodePools = &nodePools
+
+	return &output
+}
+
+func buildMk8sHetznerProvider(specs []interface{}) *client.Mk8sHetznerProvider {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.Mk8sHetznerProvider{
+		Region:           GetString(spec["region"]),
+		Networking:       buildMk8sNetworking(spec["networking"].([]interface{})),
+		PreInstallScript: GetString(spec["pre_install_script"]),
+		TokenSecretLink:  GetString(spec["token_secret_link"]),
+		NetworkId:        GetString(spec["network_id"]),
+		Image:            GetString(spec["image"]),
+		SshKey:           GetString(spec["ssh_key"]),
+		Autoscaler:       buildMk8sAutoscaler(spec["autoscaler"].([]interface{})),
+	}
+
+	if spec["hetzner_labels"] != nil {
+		output.HetznerLabels = GetStringMap(spec["hetzner_labels"])
+	}
+
+	nodePools := []client.Mk8sHetznerPool{}
+
+	for _, item := range spec["node_pool"].([]interface{}) {
+
+		nodePool := item.(map[string]interface{})
+
+		nodePools = append(nodePools, client.Mk8sHetznerPool{
+			Mk8sNodePool:  buildMk8sNodePool(nodePool),
+			ServerType:    GetString(nodePool["server_type"]),
+			OverrideImage: GetString(nodePool["override_image"]),
+			MinSize:       GetInt(nodePool["min_size"]),
+			MaxSize:       GetInt(nodePool["max_size"]),
+		})
+	}
+
+	output.NodePools = &nodePools
+
+	return &output
+}
+
+func buildMk8sAwsProvider(specs []interface{}) *client.Mk8sAwsProvider {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.Mk8sAwsProvider{
+		Region:               GetString(spec["region"]),
+		SkipCreateRoles:      GetBool(spec["skip_create_roles"]),
+		Networking:           buildMk8sNetworking(spec["networking"].([]interface{})),
+		PreInstallScript:     GetString(spec["pre_install_script"]),
+		Image:                buildMk8sAwsAmi(spec["image"].([]interface{})),
+		DeployRoleArn:        GetString(spec["deploy_role_arn"]),
+		VpcId:                GetString(spec["vpc_id"]),
+		KeyPair:              GetString(spec["key_pair"]),
+		DiskEncryptionKeyArn: GetString(spec["disk_encryption_key_arn"]),
+		SecurityGroupIds:     buildStringArray(spec["security_group_ids"].([]interface{})),
+		Autoscaler:           buildMk8sAutoscaler(spec["autoscaler"].([]interface{})),
+	}
+
+	if spec["aws_tags"] != nil {
+		output.AwsTags = GetStringMap(spec["aws_tags"])
+	}
+
+	nodePools := []client.Mk8sAwsPool{}
+
+	for _, item := range spec["node_pool"].([]interface{}) {
+
+		nodePool := item.(map[string]interface{})
+
+		nodePools = append(nodePools, client.Mk8sAwsPool{
+			Mk8sNodePool:                        buildMk8sNodePool(nodePool),
+			InstanceTypes:                       buildStringArray(nodePool["instance_types"].([]interface{})),
+			OverrideImage:                       buildMk8sAwsAmi(nodePool["override_image"].([]interface{})),
+			BootDiskSize:                        GetInt(nodePool["boot_disk_size"]),
+			MinSize:                             GetInt(nodePool["min_size"]),
+			MaxSize:                             GetInt(nodePool["max_size"]),
+			OnDemandBaseCapacity:                GetInt(nodePool["on_demand_base_capacity"]),
+			OnDemandPercentageAboveBaseCapacity: GetInt(nodePool["on_demand_percentage_above_base_capacity"]),
+			SpotAllocationStrategy:              GetString(nodePool["spot_allocation_strategy"]),
+			SubnetIds:                           buildStringArray(nodePool["subnet_ids"].([]interface{})),
+			ExtraSecurityGroupIds:               buildStringArray(nodePool["extra_security_group_ids"].([]interface{})),
+		})
+	}
+
+	output.NodePools = &nodePools
+
+	return &output
+}
+
+func buildMk8sNetworking(specs []interface{}) *client.Mk8sNetworking {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+
+	return &client.Mk8sNetworking{
+		ServiceNetwork: GetString(spec["service_network"]),
+		PodNetwork:     GetString(spec["pod_network"]),
+	}
+}
+
+func buildMk8sNodePool(spec map[string]interface{}) client.Mk8sNodePool {
+
+	output := client.Mk8sNodePool{
+		Name: GetString(spec["name"]),
+	}
+
+	if spec["labels"] != nil {
+		output.Labels = GetStringMap(spec["labels"])
+	}
+
+	if taints, ok := spec["taint"].([]interface{}); ok && len(taints) > 0 {
+
+		collection := []client.Mk8sTaint{}
+
+		for _, item := range taints {
+
+			taint := item.(map[string]interface{})
+
+			collection = append(collection, client.Mk8sTaint{
+				Key:    GetString(taint["key"]),
+				Value:  GetString(taint["value"]),
+				Effect: GetString(taint["effect"]),
+			})
+		}
+
+		output.Taints = &collection
+	}
+
+	return output
+}
+
+func buildMk8sAwsAmi(specs []interface{}) *client.Mk8sAwsAmi {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+
+	return &client.Mk8sAwsAmi{
+		Recommended: GetString(spec["recommended"]),
+		Exact:       GetString(spec["exact"]),
+	}
+}
+
+func buildMk8sAutoscaler(specs []interface{}) *client.Mk8sAutoscaler {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+
+	return &client.Mk8sAutoscaler{
+		Expander:             buildStringArray(spec["expander"].([]interface{})),
+		UnneededTime:         GetString(spec["unneeded_time"]),
+		UnreadyTime:          GetString(spec["unready_time"]),
+		UtilizationThreshold: GetFloat64(spec["utilization_threshold"]),
+	}
+}
+
+func buildMk8sAddOns(specs []interface{}) *client.Mk8sAddOns {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.Mk8sAddOns{}
+
+	if spec["dashboard"] != nil && spec["dashboard"].(bool) {
+		output.Dashboard = &client.Mk8sNonCustomizableAddOn{}
+	}
+
+	if logs, ok := spec["logs"].([]interface{}); ok && len(logs) > 0 && logs[0] != nil {
+
+		log := logs[0].(map[string]interface{})
+
+		output.Logs = &client.Mk8sLogsAddOn{
+			AuditEnabled:      GetBool(log["audit_enabled"]),
+			IncludeNamespaces: GetString(log["include_namespaces"]),
+			ExcludeNamespaces: GetString(log["exclude_namespaces"]),
+		}
+	}
+
+	if metrics, ok := spec["metrics"].([]interface{}); ok && len(metrics) > 0 && metrics[0] != nil {
+
+		metric := metrics[0].(map[string]interface{})
+
+		output.Metrics = &client.Mk8sMetricsAddOn{
+			KubeState:    GetBool(metric["kube_state"]),
+			CoreDns:      GetBool(metric["core_dns"]),
+			Kubelet:      GetBool(metric["kubelet"]),
+			Apiserver:    GetBool(metric["api_server"]),
+			NodeExporter: GetBool(metric["node_exporter"]),
+			Cadvisor:     GetBool(metric["cadvisor"]),
+		}
+	}
+
+	return &output
+}
+
+/*** Flatten ***/
+func flattenMk8sFirewall(firewall *[]client.Mk8sFirewall) []interface{} {
+
+	if firewall == nil || len(*firewall) == 0 {
+		return nil
+	}
+
+	output := make([]interface{}, len(*firewall))
+
+	for i, rule := range *firewall {
+
+		spec := map[string]interface{}{}
+
+		if rule.SourceCIDR != nil {
+			spec["source_cidr"] = *rule.SourceCIDR
+		}
+
+		if rule.Description != nil {
+			spec["description"] = *rule.Description
+		}
+
+		output[i] = spec
+	}
+
+	return output
+}
+
+func flattenMk8sGenericProvider(generic *client.Mk8sGenericProvider) []interface{} {
+
+	if generic == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if generic.Location != nil {
+		spec["location"] = *generic.Location
+	}
+
+	spec["networking"] = flattenMk8sNetworking(generic.Networking)
+
+	if generic.NodePools != nil {
+
+		nodePools := make([]interface{}, len(*generic.NodePools))
+
+		for i, nodePool := range *generic.NodePools {
+			nodePools[i] = flattenMk8sNodePool(nodePool)
+		}
+
+		spec["node_pool"] = nodePools
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sHetznerProvider(hetzner *client.Mk8sHetznerProvider) []interface{} {
+
+	if hetzner == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if hetzner.Region != nil {
+		spec["region"] = *hetzner.Region
+	}
+
+	if hetzner.HetznerLabels != nil {
+		spec["hetzner_labels"] = *hetzner.HetznerLabels
+	}
+
+	spec["networking"] = flattenMk8sNetworking(hetzner.Networking)
+
+	if hetzner.PreInstallScript != nil {
+		spec["pre_install_script"] = *hetzner.PreInstallScript
+	}
+
+	if hetzner.TokenSecretLink != nil {
+		spec["token_secret_link"] = *hetzner.TokenSecretLink
+	}
+
+	if hetzner.NetworkId != nil {
+		spec["network_id"] = *hetzner.NetworkId
+	}
+
+	if hetzner.NodePools != nil {
+
+		nodePools := make([]interface{}, len(*hetzner.NodePools))
+
+		for i, nodePool := range *hetzner.NodePools {
+
+			flattened := flattenMk8sNodePool(nodePool.Mk8sNodePool)
+
+			if nodePool.ServerType != nil {
+				flattened["server_type"] = *nodePool.ServerType
+			}
+
+			if nodePool.OverrideImage != nil {
+				flattened["override_image"] = *nodePool.OverrideImage
+			}
+
+			if nodePool.MinSize != nil {
+				flattened["min_size"] = *nodePool.MinSize
+			}
+
+			if nodePool.MaxSize != nil {
+				flattened["max_size"] = *nodePool.MaxSize
+			}
+
+			nodePools[i] = flattened
+		}
+
+		spec["node_pool"] = nodePools
+	}
+
+	if hetzner.Image != nil {
+		spec["image"] = *hetzner.Image
+	}
+
+	if hetzner.SshKey != nil {
+		spec["ssh_key"] = *hetzner.SshKey
+	}
+
+	spec["autoscaler"] = flattenMk8sAutoscaler(hetzner.Autoscaler)
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sAwsProvider(aws *client.Mk8sAwsProvider) []interface{} {
+
+	if aws == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if aws.Region != nil {
+		spec["region"] = *aws.Region
+	}
+
+	if aws.AwsTags != nil {
+		spec["aws_tags"] = *aws.AwsTags
+	}
+
+	if aws.SkipCreateRoles != nil {
+		spec["skip_create_roles"] = *aws.SkipCreateRoles
+	}
+
+	spec["networking"] = flattenMk8sNetworking(aws.Networking)
+
+	if aws.PreInstallScript != nil {
+		spec["pre_install_script"] = *aws.PreInstallScript
+	}
+
+	spec["image"] = flattenMk8sAwsAmi(aws.Image)
+
+	if aws.DeployRoleArn != nil {
+		spec["deploy_role_arn"] = *aws.DeployRoleArn
+	}
+
+	if aws.VpcId != nil {
+		spec["vpc_id"] = *aws.VpcId
+	}
+
+	if aws.KeyPair != nil {
+		spec["key_pair"] = *aws.KeyPair
+	}
+
+	if aws.DiskEncryptionKeyArn != nil {
+		spec["disk_encryption_key_arn"] = *aws.DiskEncryptionKeyArn
+	}
+
+	spec["security_group_ids"] = flattenStringsArray(aws.SecurityGroupIds)
+
+	if aws.NodePools != nil {
+
+		nodePools := make([]interface{}, len(*aws.NodePools))
+
+		for i, nodePool := range *aws.NodePools {
+
+			flattened := flattenMk8sNodePool(nodePool.Mk8sNodePool)
+
+			flattened["instance_types"] = flattenStringsArray(nodePool.InstanceTypes)
+			flattened["override_image"] = flattenMk8sAwsAmi(nodePool.OverrideImage)
+
+			if nodePool.BootDiskSize != nil {
+				flattened["boot_disk_size"] = *nodePool.BootDiskSize
+			}
+
+			if nodePool.MinSize != nil {
+				flattened["min_size"] = *nodePool.MinSize
+			}
+
+			if nodePool.MaxSize != nil {
+				flattened["max_size"] = *nodePool.MaxSize
+			}
+
+			if nodePool.OnDemandBaseCapacity != nil {
+				flattened["on_demand_base_capacity"] = *nodePool.OnDemandBaseCapacity
+			}
+
+			if nodePool.OnDemandPercentageAboveBaseCapacity != nil {
+				flattened["on_demand_percentage_above_base_capacity"] = *nodePool.OnDemandPercentageAboveBaseCapacity
+			}
+
+			if nodePool.SpotAllocationStrategy != nil {
+				flattened["spot_allocation_strategy"] = *nodePool.SpotAllocationStrategy
+			}
+
+			flattened["subnet_ids"] = flattenStringsArray(nodePool.SubnetIds)
+			flattened["extra_security_group_ids"] = flattenStringsArray(nodePool.ExtraSecurityGroupIds)
+
+			nodePools[i] = flattened
+		}
+
+		spec["node_pool"] = nodePools
+	}
+
+	spec["autoscaler"] = flattenMk8sAutoscaler(aws.Autoscaler)
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sNetworking(networking *client.Mk8sNetworking) []interface{} {
+
+	if networking == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if networking.ServiceNetwork != nil {
+		spec["service_network"] = *networking.ServiceNetwork
+	}
+
+	if networking.PodNetwork != nil {
+		spec["pod_network"] = *networking.PodNetwork
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sNodePool(nodePool client.Mk8sNodePool) map[string]interface{} {
+
+	spec := map[string]interface{}{}
+
+	if nodePool.Name != nil {
+		spec["name"] = *nodePool.Name
+	}
+
+	if nodePool.Labels != nil {
+		spec["labels"] = *nodePool.Labels
+	}
+
+	if nodePool.Taints != nil && len(*nodePool.Taints) > 0 {
+
+		taints := make([]interface{}, len(*nodePool.Taints))
+
+		for i, taint := range *nodePool.Taints {
+
+			flattened := map[string]interface{}{}
+
+			if taint.Key != nil {
+				flattened["key"] = *taint.Key
+			}
+
+			if taint.Value != nil {
+				flattened["value"] = *taint.Value
+			}
+
+			if taint.Effect != nil {
+				flattened["effect"] = *taint.Effect
+			}
+
+			taints[i] = flattened
+		}
+
+		spec["taint"] = taints
+	}
+
+	return spec
+}
+
+func flattenMk8sAwsAmi(ami *client.Mk8sAwsAmi) []interface{} {
+
+	if ami == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if ami.Recommended != nil {
+		spec["recommended"] = *ami.Recommended
+	}
+
+	if ami.Exact != nil {
+		spec["exact"] = *ami.Exact
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sAutoscaler(autoscaler *client.Mk8sAutoscaler) []interface{} {
+
+	if autoscaler == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	spec["expander"] = flattenStringsArray(autoscaler.Expander)
+
+	if autoscaler.UnneededTime != nil {
+		spec["unneeded_time"] = *autoscaler.UnneededTime
+	}
+
+	if autoscaler.UnreadyTime != nil {
+		spec["unready_time"] = *autoscaler.UnreadyTime
+	}
+
+	if autoscaler.UtilizationThreshold != nil {
+		spec["utilization_threshold"] = *autoscaler.UtilizationThreshold
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sAddOns(addOns *client.Mk8sAddOns) []interface{} {
+
+	if addOns == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{
+		"dashboard": addOns.Dashboard != nil,
+	}
+
+	if addOns.Logs != nil {
+
+		logs := map[string]interface{}{}
+
+		if addOns.Logs.AuditEnabled != nil {
+			logs["audit_enabled"] = *addOns.Logs.AuditEnabled
+		}
+
+		if addOns.Logs.IncludeNamespaces != nil {
+			logs["include_namespaces"] = *addOns.Logs.IncludeNamespaces
+		}
+
+		if addOns.Logs.ExcludeNamespaces != nil {
+			logs["exclude_namespaces"] = *addOns.Logs.ExcludeNamespaces
+		}
+
+		spec["logs"] = []interface{}{logs}
+	}
+
+	if addOns.Metrics != nil {
+
+		metrics := map[string]interface{}{}
+
+		if addOns.Metrics.KubeState != nil {
+			metrics["kube_state"] = *addOns.Metrics.KubeState
+		}
+
+		if addOns.Metrics.CoreDns != nil {
+			metrics["core_dns"] = *addOns.Metrics.CoreDns
+		}
+
+		if addOns.Metrics.Kubelet != nil {
+			metrics["kubelet"] = *addOns.Metrics.Kubelet
+		}
+
+		if addOns.Metrics.Apiserver != nil {
+			metrics["api_server"] = *addOns.Metrics.Apiserver
+		}
+
+		if addOns.Metrics.NodeExporter != nil {
+			metrics["node_exporter"] = *addOns.Metrics.NodeExporter
+		}
+
+		if addOns.Metrics.Cadvisor != nil {
+			metrics["cadvisor"] = *addOns.Metrics.Cadvisor
+		}
+
+		spec["metrics"] = []interface{}{metrics}
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sStatus(status *client.Mk8sStatus) []interface{} {
+
+	if status == nil {
+		return nil
+	}
+
+	spec := map[string]interface{}{}
+
+	if status.OidcProviderUrl != nil {
+		spec["oidc_provider_url"] = *status.OidcProviderUrl
+	}
+
+	if status.ServerUrl != nil {
+		spec["server_url"] = *status.ServerUrl
+	}
+
+	if status.HomeLocation != nil {
+		spec["home_location"] = *status.HomeLocation
+	}
+
+	if status.AddOns != nil {
+
+		addOns := map[string]interface{}{}
+
+		health := []interface{}{}
+
+		if status.AddOns.Dashboard != nil {
+
+			if status.AddOns.Dashboard.Url != nil {
+				addOns["dashboard_url"] = *status.AddOns.Dashboard.Url
+			}
+
+			health = append(health, flattenMk8sAddOnHealth("dashboard", status.AddOns.Dashboard.Mk8sAddOnHealth))
+		}
+
+		if status.AddOns.Logs != nil {
+
+			if status.AddOns.Logs.LokiAddress != nil {
+				addOns["loki_address"] = *status.AddOns.Logs.LokiAddress
+			}
+
+			health = append(health, flattenMk8sAddOnHealth("logs", status.AddOns.Logs.Mk8sAddOnHealth))
+		}
+
+		if status.AddOns.Metrics != nil {
+
+			health = append(health, flattenMk8sAddOnHealth("metrics", status.AddOns.Metrics.Mk8sAddOnHealth))
+
+			if status.AddOns.Metrics.PrometheusEndpoint != nil {
+				addOns["prometheus_endpoint"] = *status.AddOns.Metrics.PrometheusEndpoint
+			}
+
+			if status.AddOns.Metrics.RemoteWriteConfig != nil {
+
+				jsonData, err := json.Marshal(*status.AddOns.Metrics.RemoteWriteConfig)
+
+				if err != nil {
+					addOns["remote_write_config"] = fmt.Sprintf("Error serializing to JSON: %s", err)
+				} else {
+					addOns["remote_write_config"] = string(jsonData)
+				}
+			}
+		}
+
+		if len(health) > 0 {
+			addOns["health"] = health
+		}
+
+		spec["add_ons"] = []interface{}{addOns}
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMk8sAddOnHealth(name string, health client.Mk8sAddOnHealth) map[string]interface{} {
+
+	output := map[string]interface{}{
+		"name": name,
+	}
+
+	if health.Health != nil {
+		output["status"] = *health.Health
+	}
+
+	if health.Message != nil {
+		output["message"] = *health.Message
+	}
+
+	if health.LastChecked != nil {
+		output["last_checked"] = *health.LastChecked
+	}
+
+	return output
+}
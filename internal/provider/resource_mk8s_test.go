@@ -0,0 +1,388 @@
+package cpln
+
+import (
+	"fmt"
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+/*** Acc Provider Tests ***/
+
+func TestAccControlPlaneMk8s_basic(t *testing.T) {
+
+	var mk8s client.Mk8s
+
+	name := "mk8s-generic-" + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t, "MK8S") },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckControlPlaneMk8sCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlPlaneMk8sGeneric(name, "Mk8s created using terraform for acceptance tests", "1.28.4"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneMk8sExists("cpln_mk8s.new", name, &mk8s),
+					resource.TestCheckResourceAttr("cpln_mk8s.new", "version", "1.28.4"),
+					resource.TestCheckResourceAttr("cpln_mk8s.new", "generic_provider.0.location", "aws-eu-central-1"),
+					resource.TestCheckResourceAttr("cpln_mk8s.new", "generic_provider.0.node_pool.0.name", "my-node-pool"),
+					resource.TestCheckResourceAttr("cpln_mk8s.new", "add_ons.0.dashboard", "true"),
+				),
+			},
+			{
+				Config: testAccControlPlaneMk8sGeneric(name, "Mk8s updated using terraform for acceptance tests", "1.28.4"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneMk8sExists("cpln_mk8s.new", name, &mk8s),
+					resource.TestCheckResourceAttr("cpln_mk8s.new", "description", "Mk8s updated using terraform for acceptance tests"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlPlaneMk8sGeneric(name, description, version string) string {
+
+	TestLogger.Printf("Inside testAccControlPlaneMk8sGeneric")
+
+	return fmt.Sprintf(`
+
+	resource "cpln_mk8s" "new" {
+		name        = "%s"
+		description = "%s"
+		version     = "%s"
+
+		tags = {
+			terraform_generated = "true"
+			acceptance_test     = "true"
+		}
+
+		firewall {
+			source_cidr = "192.168.1.255"
+			description = "my firewall rule"
+		}
+
+		generic_provider {
+			location = "aws-eu-central-1"
+
+			networking {
+				service_network = "10.43.0.0/16"
+				pod_network     = "10.42.0.0/16"
+			}
+
+			node_pool {
+				name = "my-node-pool"
+
+				labels = {
+					hello = "world"
+				}
+
+				taint {
+					key    = "hello"
+					value  = "world"
+					effect = "NoSchedule"
+				}
+			}
+		}
+
+		add_ons {
+			dashboard = true
+
+			logs {
+				audit_enabled      = true
+				include_namespaces = "^elastic"
+				exclude_namespaces = "^elastic"
+			}
+
+			metrics {
+				kube_state    = true
+				core_dns      = true
+				kubelet       = true
+				api_server    = true
+				node_exporter = true
+				cadvisor      = true
+			}
+		}
+	}
+	`, name, description, version)
+}
+
+func testAccCheckControlPlaneMk8sExists(resourceName, mk8sName string, mk8s *client.Mk8s) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		TestLogger.Printf("Inside testAccCheckControlPlaneMk8sExists. Resources Length: %d", len(s.RootModule().Resources))
+
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", s)
+		}
+
+		if rs.Primary.ID != mk8sName {
+			return fmt.Errorf("Mk8s name does not match")
+		}
+
+		client := testAccProvider.Meta().(*client.Client)
+
+		m, _, err := client.GetMk8s(mk8sName)
+
+		if err != nil {
+			return err
+		}
+
+		if *m.Name != mk8sName {
+			return fmt.Errorf("Mk8s name does not match")
+		}
+
+		*mk8s = *m
+
+		return nil
+	}
+}
+
+func testAccCheckControlPlaneMk8sCheckDestroy(s *terraform.State) error {
+
+	if len(s.RootModule().Resources) == 0 {
+		return fmt.Errorf("Error In CheckDestroy. No Resources To Verify")
+	}
+
+	c := testAccProvider.Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "cpln_mk8s" {
+			continue
+		}
+
+		mk8sName := rs.Primary.ID
+
+		mk8s, _, _ := c.GetMk8s(mk8sName)
+		if mk8s != nil {
+			return fmt.Errorf("Mk8s still exists. Name: %s", *mk8s.Name)
+		}
+	}
+
+	return nil
+}
+
+/*** Unit Tests ***/
+// Build //
+func TestControlPlane_BuildMk8sGenericProvider(t *testing.T) {
+
+	generic, expectedGeneric, _ := generateTestMk8sGenericProvider()
+
+	if diff := deep.Equal(generic, expectedGeneric); diff != nil {
+		t.Errorf("Mk8s Generic Provider was not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_BuildMk8sAddOns(t *testing.T) {
+
+	addOns, expectedAddOns, _ := generateTestMk8sAddOns()
+
+	if diff := deep.Equal(addOns, expectedAddOns); diff != nil {
+		t.Errorf("Mk8s Add-ons were not built correctly, Diff: %s", diff)
+	}
+}
+
+// Flatten //
+func TestControlPlane_FlattenMk8sGenericProvider(t *testing.T) {
+
+	_, expectedGeneric, expectedFlatten := generateTestMk8sGenericProvider()
+	flattenedGeneric := flattenMk8sGenericProvider(expectedGeneric)
+
+	if diff := deep.Equal(expectedFlatten, flattenedGeneric); diff != nil {
+		t.Errorf("Mk8s Generic Provider was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenMk8sAddOns(t *testing.T) {
+
+	_, expectedAddOns, expectedFlatten := generateTestMk8sAddOns()
+	flattenedAddOns := flattenMk8sAddOns(expectedAddOns)
+
+	if diff := deep.Equal(expectedFlatten, flattenedAddOns); diff != nil {
+		t.Errorf("Mk8s Add-ons were not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenMk8sStatus(t *testing.T) {
+
+	healthy := "healthy"
+	degraded := "degraded"
+	message := "loki is not ready"
+	lastChecked := "2024-01-01T00:00:00Z"
+	url := "https://dashboard.example.com"
+	lokiAddress := "https://logs.example.com"
+
+	status := &client.Mk8sStatus{
+		AddOns: &client.Mk8sAddOnsStatus{
+			Dashboard: &client.Mk8sDashboardAddOnStatus{
+				Mk8sAddOnHealth: client.Mk8sAddOnHealth{
+					Health:      &healthy,
+					LastChecked: &lastChecked,
+				},
+				Url: &url,
+			},
+			Logs: &client.Mk8sLogsAddOnStatus{
+				Mk8sAddOnHealth: client.Mk8sAddOnHealth{
+					Health:  &degraded,
+					Message: &message,
+				},
+				LokiAddress: &lokiAddress,
+			},
+		},
+	}
+
+	expectedFlatten := []interface{}{
+		map[string]interface{}{
+			"add_ons": []interface{}{
+				map[string]interface{}{
+					"dashboard_url": url,
+					"loki_address":  lokiAddress,
+					"health": []interface{}{
+						map[string]interface{}{
+							"name":         "dashboard",
+							"status":       healthy,
+							"last_checked": lastChecked,
+						},
+						map[string]interface{}{
+							"name":    "logs",
+							"status":  degraded,
+							"message": message,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(expectedFlatten, flattenMk8sStatus(status)); diff != nil {
+		t.Errorf("Mk8s Status was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+/*** Generate ***/
+func generateTestMk8sGenericProvider() (*client.Mk8sGenericProvider, *client.Mk8sGenericProvider, []interface{}) {
+
+	location := "aws-eu-central-1"
+	serviceNetwork := "10.43.0.0/16"
+	podNetwork := "10.42.0.0/16"
+	nodePoolName := "my-node-pool"
+	labels := map[string]interface{}{
+		"hello": "world",
+	}
+	taintKey := "hello"
+	taintValue := "world"
+	taintEffect := "NoSchedule"
+
+	flattened := []interface{}{
+		map[string]interface{}{
+			"location": location,
+			"networking": []interface{}{
+				map[string]interface{}{
+					"service_network": serviceNetwork,
+					"pod_network":     podNetwork,
+				},
+			},
+			"node_pool": []interface{}{
+				map[string]interface{}{
+					"name":   nodePoolName,
+					"labels": labels,
+					"taint": []interface{}{
+						map[string]interface{}{
+							"key":    taintKey,
+							"value":  taintValue,
+							"effect": taintEffect,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generic := buildMk8sGenericProvider(flattened)
+	expectedGeneric := client.Mk8sGenericProvider{
+		Location: &location,
+		Networking: &client.Mk8sNetworking{
+			ServiceNetwork: &serviceNetwork,
+			PodNetwork:     &podNetwork,
+		},
+		NodePools: &[]client.Mk8sNodePool{
+			{
+				Name:   &nodePoolName,
+				Labels: &labels,
+				Taints: &[]client.Mk8sTaint{
+					{
+						Key:    &taintKey,
+						Value:  &taintValue,
+						Effect: &taintEffect,
+					},
+				},
+			},
+		},
+	}
+
+	return generic, &expectedGeneric, flattened
+}
+
+func generateTestMk8sAddOns() (*client.Mk8sAddOns, *client.Mk8sAddOns, []interface{}) {
+
+	auditEnabled := true
+	includeNamespaces := "^elastic"
+	excludeNamespaces := "^kube-system"
+	kubeState := true
+	coreDns := false
+	kubelet := true
+	apiServer := false
+	nodeExporter := true
+	cadvisor := false
+
+	flattened := []interface{}{
+		map[string]interface{}{
+			"dashboard": true,
+			"logs": []interface{}{
+				map[string]interface{}{
+					"audit_enabled":      auditEnabled,
+					"include_namespaces": includeNamespaces,
+					"exclude_namespaces": excludeNamespaces,
+				},
+			},
+			"metrics": []interface{}{
+				map[string]interface{}{
+					"kube_state":    kubeState,
+					"core_dns":      coreDns,
+					"kubelet":       kubelet,
+					"api_server":    apiServer,
+					"node_exporter": nodeExporter,
+					"cadvisor":      cadvisor,
+				},
+			},
+		},
+	}
+
+	addOns := buildMk8sAddOns(flattened)
+	expectedAddOns := client.Mk8sAddOns{
+		Dashboard: &client.Mk8sNonCustomizableAddOn{},
+		Logs: &client.Mk8sLogsAddOn{
+			AuditEnabled:      &auditEnabled,
+			IncludeNamespaces: &includeNamespaces,
+			ExcludeNamespaces: &excludeNamespaces,
+		},
+		Metrics: &client.Mk8sMetricsAddOn{
+			KubeState:    &kubeState,
+			CoreDns:      &coreDns,
+			Kubelet:      &kubelet,
+			Apiserver:    &apiServer,
+			NodeExporter: &nodeExporter,
+			Cadvisor:     &cadvisor,
+		},
+	}
+
+	return addOns, &expectedAddOns, flattened
+}
@@ -0,0 +1,293 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Main ***/
+func resourceQuota() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceQuotaCreate,
+		ReadContext:   resourceQuotaRead,
+		UpdateContext: resourceQuotaUpdate,
+		DeleteContext: resourceQuotaDelete,
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     DescriptionValidator,
+				DiffSuppressFunc: DiffSuppressDescription,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: TagValidator,
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dimension": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"max": {
+				Type:     schema.TypeFloat,
+				Required: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(float64)
+
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q must be greater than or equal to 0, got: %f", key, v))
+					}
+
+					return
+				},
+			},
+			"unit": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"target_kind": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "gvc",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(string)
+
+					if v != "org" && v != "gvc" {
+						errs = append(errs, fmt.Errorf("%q must be either 'org' or 'gvc', got: %s", key, v))
+					}
+
+					return
+				},
+			},
+			"target_links": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          StringSchema(),
+				ConflictsWith: []string{"target_query"},
+			},
+			"target_query": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Elem:          QuerySchemaResource(),
+				ConflictsWith: []string{"target_links"},
+			},
+			"current": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{},
+	}
+}
+
+func setQuota(d *schema.ResourceData, quota *client.Quota) diag.Diagnostics {
+
+	if quota == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(*quota.Name)
+
+	if err := SetBase(d, quota.Base); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := SetSelfLink(quota.Links, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if quota.Status != nil {
+		if err := d.Set("current", quota.Status.Current); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if quota.Spec == nil {
+		return nil
+	}
+
+	if err := d.Set("dimension", quota.Spec.Dimension); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("max", quota.Spec.Max); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("unit", quota.Spec.Unit); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if quota.Spec.TargetKind != nil {
+		if err := d.Set("target_kind", quota.Spec.TargetKind); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("target_links", flattenTargetLinks(quota.Spec.TargetLinks)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	targetQuery, err := FlattenQueryHelper(quota.Spec.TargetQuery)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("target_query", targetQuery); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceQuotaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+
+	quota := client.Quota{}
+	quota.Name = GetString(d.Get("name"))
+	quota.Description = GetDescriptionString(d.Get("description"), *quota.Name)
+	quota.Tags = GetStringMap(d.Get("tags"))
+	quota.Spec = buildQuotaSpec(c.Org, d)
+
+	newQuota, code, err := c.CreateQuota(quota)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setQuota(d, newQuota)
+}
+
+func resourceQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	quota, code, err := c.GetQuota(d.Id())
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return setQuota(d, quota)
+}
+
+func resourceQuotaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	if d.HasChanges("description", "tags", "max", "unit", "target_links", "target_query") {
+
+		c := m.(*client.Client)
+
+		quotaToUpdate := client.Quota{}
+		quotaToUpdate.Name = GetString(d.Get("name"))
+
+		if d.HasChange("description") {
+			quotaToUpdate.Description = GetDescriptionString(d.Get("description"), *quotaToUpdate.Name)
+		}
+
+		if d.HasChange("tags") {
+			quotaToUpdate.Tags = GetTagChanges(d)
+		}
+
+		if d.HasChanges("max", "unit", "target_links", "target_query") {
+			quotaToUpdate.SpecReplace = buildQuotaSpec(c.Org, d)
+		}
+
+		updatedQuota, _, err := c.UpdateQuota(quotaToUpdate)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return setQuota(d, updatedQuota)
+	}
+
+	return nil
+}
+
+func resourceQuotaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	err := c.DeleteQuota(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+/*** Build ***/
+func buildQuotaSpec(org string, d *schema.ResourceData) *client.QuotaSpec {
+
+	targetKind := d.Get("target_kind").(string)
+
+	return &client.QuotaSpec{
+		Dimension:   GetString(d.Get("dimension")),
+		Max:         GetFloat64(d.Get("max")),
+		Unit:        GetString(d.Get("unit")),
+		TargetKind:  GetString(targetKind),
+		TargetLinks: buildQuotaTargetLinks(org, targetKind, d.Get("target_links").(*schema.Set).List()),
+		TargetQuery: BuildQueryHelper(targetKind, d.Get("target_query")),
+	}
+}
+
+func buildQuotaTargetLinks(org string, kind string, targets []interface{}) *[]string {
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	targetLinks := []string{}
+
+	for _, target := range targets {
+
+		if kind == "org" {
+			targetLinks = append(targetLinks, fmt.Sprintf("/org/%s", target))
+		} else {
+			targetLinks = append(targetLinks, fmt.Sprintf("/org/%s/%s/%s", org, kind, target))
+		}
+	}
+
+	return &targetLinks
+}
@@ -0,0 +1,155 @@
+package cpln
+
+import (
+	"fmt"
+	"testing"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+/*** Acc Provider Tests ***/
+
+func TestAccControlPlaneQuota_basic(t *testing.T) {
+
+	var quota client.Quota
+
+	random := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	name := "quota-" + random
+	gvcName := "gvc-quota-" + random
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t, "QUOTA") },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckControlPlaneQuotaCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccControlPlaneQuota(gvcName, name, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneQuotaExists("cpln_quota.new", name, &quota),
+					resource.TestCheckResourceAttr("cpln_quota.new", "dimension", "cpu"),
+					resource.TestCheckResourceAttr("cpln_quota.new", "max", "10"),
+					resource.TestCheckResourceAttr("cpln_quota.new", "target_links.#", "1"),
+				),
+			},
+			{
+				Config: testAccControlPlaneQuota(gvcName, name, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckControlPlaneQuotaExists("cpln_quota.new", name, &quota),
+					resource.TestCheckResourceAttr("cpln_quota.new", "max", "20"),
+				),
+			},
+		},
+	})
+}
+
+func testAccControlPlaneQuota(gvcName, name string, max int) string {
+
+	TestLogger.Printf("Inside testAccControlPlaneQuota")
+
+	return fmt.Sprintf(`
+
+	resource "cpln_gvc" "new" {
+		name      = "%s"
+		locations = ["aws-eu-central-1"]
+	}
+
+	resource "cpln_quota" "new" {
+		name        = "%s"
+		description = "Quota created using terraform for acceptance tests"
+
+		dimension    = "cpu"
+		max          = %d
+		unit         = "cores"
+		target_kind  = "gvc"
+		target_links = [cpln_gvc.new.name]
+	}
+	`, gvcName, name, max)
+}
+
+func testAccCheckControlPlaneQuotaExists(resourceName, quotaName string, quota *client.Quota) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+
+		TestLogger.Printf("Inside testAccCheckControlPlaneQuotaExists. Resources Length: %d", len(s.RootModule().Resources))
+
+		rs, ok := s.RootModule().Resources[resourceName]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", s)
+		}
+
+		if rs.Primary.ID != quotaName {
+			return fmt.Errorf("Quota name does not match")
+		}
+
+		client := testAccProvider.Meta().(*client.Client)
+
+		q, _, err := client.GetQuota(quotaName)
+
+		if err != nil {
+			return err
+		}
+
+		if *q.Name != quotaName {
+			return fmt.Errorf("Quota name does not match")
+		}
+
+		*quota = *q
+
+		return nil
+	}
+}
+
+func testAccCheckControlPlaneQuotaCheckDestroy(s *terraform.State) error {
+
+	if len(s.RootModule().Resources) == 0 {
+		return fmt.Errorf("Error In CheckDestroy. No Resources To Verify")
+	}
+
+	c := testAccProvider.Meta().(*client.Client)
+
+	for _, rs := range s.RootModule().Resources {
+
+		if rs.Type != "cpln_quota" {
+			continue
+		}
+
+		quotaName := rs.Primary.ID
+
+		quota, _, _ := c.GetQuota(quotaName)
+		if quota != nil {
+			return fmt.Errorf("Quota still exists. Name: %s", *quota.Name)
+		}
+	}
+
+	return nil
+}
+
+/*** Unit Tests ***/
+// Build //
+func TestControlPlane_BuildQuotaTargetLinks(t *testing.T) {
+
+	org := "unit-test-org"
+
+	gvcLinks := buildQuotaTargetLinks(org, "gvc", []interface{}{"gvc-01"})
+	expectedGvcLinks := []string{"/org/unit-test-org/gvc/gvc-01"}
+
+	if diff := deep.Equal(gvcLinks, &expectedGvcLinks); diff != nil {
+		t.Errorf("Quota GVC target links were not built correctly, Diff: %s", diff)
+	}
+
+	orgLinks := buildQuotaTargetLinks(org, "org", []interface{}{org})
+	expectedOrgLinks := []string{"/org/unit-test-org"}
+
+	if diff := deep.Equal(orgLinks, &expectedOrgLinks); diff != nil {
+		t.Errorf("Quota org target links were not built correctly, Diff: %s", diff)
+	}
+
+	if links := buildQuotaTargetLinks(org, "gvc", []interface{}{}); links != nil {
+		t.Errorf("Quota target links should be nil when no targets are set, got: %v", *links)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
@@ -15,8 +16,9 @@ func resourceServiceAccountKey() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceServiceAccountKeyCreate,
 		ReadContext:   resourceServiceAccountKeyRead,
-		// UpdateContext: resourceServiceAccountKeyUpdate,
+		UpdateContext: resourceServiceAccountKeyUpdate,
 		DeleteContext: resourceServiceAccountKeyDelete,
+		CustomizeDiff: customizeDiffServiceAccountKey,
 		Schema: map[string]*schema.Schema{
 			"service_account_name": {
 				Type:         schema.TypeString,
@@ -34,10 +36,58 @@ func resourceServiceAccountKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"rotation_days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(int)
+
+					if v < 1 {
+						errs = append(errs, fmt.Errorf("%q must be >= 1, got: %d", key, v))
+					}
+
+					return
+				},
+			},
+			"rotate_when_changed": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"grace_period_days": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(int)
+
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q must be >= 0, got: %d", key, v))
+					}
+
+					return
+				},
+			},
 			"created": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"age_days": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"previous_key_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"previous_key_expires": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"key": {
 				Type:      schema.TypeString,
 				Sensitive: true,
@@ -58,6 +108,21 @@ func importStateServiceAccountKey(ctx context.Context, d *schema.ResourceData, m
 		return nil, fmt.Errorf("unexpected format of ID (%s), expected ID syntax: 'service_account_name:key_name'. Example: 'terraform import cpln_service_account_key.RESOURCE_NAME SERVICE_ACCOUNT_NAME:KEY_NAME'", d.Id())
 	}
 
+	c := meta.(*client.Client)
+	keys, code, err := c.ListServiceAccountKeys(parts[0])
+
+	if code == 404 {
+		return nil, fmt.Errorf("service account '%s' does not exist", parts[0])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if findServiceAccountKey(keys, parts[1]) == nil {
+		return nil, fmt.Errorf("key '%s' does not exist in service account '%s'", parts[1], parts[0])
+	}
+
 	d.Set("service_account_name", parts[0])
 	d.SetId(parts[1])
 
@@ -75,16 +140,51 @@ func resourceServiceAccountKeyCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	if key.Created == nil {
+		key.Created = GetString(time.Now().UTC().Format(time.RFC3339))
+	}
+
 	return setServiceAccountKey(d, key)
 }
 
+// customizeDiffServiceAccountKey - Plans a rotation when the key is older than rotation_days, rotate_when_changed changed
+// or the key was removed outside of Terraform, and plans the removal of the previous key once its grace period is over
+func customizeDiffServiceAccountKey(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	now := time.Now()
+	rotate := isServiceAccountKeyMissing(diff.Get("name").(string)) || diff.HasChange("rotate_when_changed") || isServiceAccountKeyRotationDue(diff.Get("created").(string), diff.Get("rotation_days").(int), now)
+	removePrevious := diff.Get("previous_key_name").(string) != "" && isServiceAccountKeyExpired(diff.Get("previous_key_expires").(string), now)
+
+	if rotate {
+		for _, key := range []string{"name", "key", "created", "age_days"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rotate || removePrevious {
+		for _, key := range []string{"previous_key_name", "previous_key_expires"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceServiceAccountKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	keyName := d.Id()
 	serviceAccountName := d.Get("service_account_name").(string)
 
 	c := m.(*client.Client)
-	sa, code, err := c.GetServiceAccount(serviceAccountName)
+	keys, code, err := c.ListServiceAccountKeys(serviceAccountName)
 
 	if code == 404 {
 		d.SetId("")
@@ -95,15 +195,28 @@ func resourceServiceAccountKeyRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	if *sa.Keys != nil {
-		for _, k := range *sa.Keys {
-			if k.Name == keyName {
-				return setServiceAccountKey(d, &k)
-			}
+	// The previous key may have been removed outside of Terraform
+	if previousKeyName := d.Get("previous_key_name").(string); previousKeyName != "" && findServiceAccountKey(keys, previousKeyName) == nil {
+		if e := setPreviousServiceAccountKey(d, "", ""); e != nil {
+			return e
 		}
 	}
 
-	return resourceServiceAccountKeyDelete(ctx, d, m)
+	if key := findServiceAccountKey(keys, keyName); key != nil {
+		return setServiceAccountKey(d, key)
+	}
+
+	// A key removed outside of Terraform (i.e. in the console) is kept in the state with an empty name, so the previous key
+	// is still tracked. The next plan recreates the key and removes the previous one
+	if err := d.Set("name", ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("key", ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
 }
 
 func setServiceAccountKey(d *schema.ResourceData, saKey *client.ServiceAccountKey) diag.Diagnostics {
@@ -129,33 +242,182 @@ func setServiceAccountKey(d *schema.ResourceData, saKey *client.ServiceAccountKe
 		return diag.FromErr(err)
 	}
 
+	if saKey.Created != nil {
+
+		if err := d.Set("created", *saKey.Created); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if ageDays, err := getServiceAccountKeyAgeDays(*saKey.Created, time.Now()); err == nil {
+			if err := d.Set("age_days", ageDays); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	return nil
 }
 
-// func resourceServiceAccountKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func setPreviousServiceAccountKey(d *schema.ResourceData, name string, expires string) diag.Diagnostics {
 
-// 	var diags diag.Diagnostics
+	if err := d.Set("previous_key_name", name); err != nil {
+		return diag.FromErr(err)
+	}
 
-// 	diags = append(diags, diag.Diagnostic{
-// 		Severity: diag.Error,
-// 		Summary:  "Unable to update Service Account Key",
-// 		Detail:   "Unable to update Service Account Key. Key can only be created or deleted",
-// 	})
+	if err := d.Set("previous_key_expires", expires); err != nil {
+		return diag.FromErr(err)
+	}
 
-// 	return diags
-// }
+	return nil
+}
 
-func resourceServiceAccountKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceServiceAccountKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	serviceAccountName := d.Get("service_account_name").(string)
+	now := time.Now()
+
+	// Planned values of the computed attributes are unknown during a rotation and would be dropped from the state on error,
+	// so the state values are set back first. Every step below then records its result before the next one can fail
+	for _, key := range []string{"name", "key", "created", "age_days", "previous_key_name", "previous_key_expires"} {
+
+		old, _ := d.GetChange(key)
+
+		if err := d.Set(key, old); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	previousKeyName := d.Get("previous_key_name").(string)
 
 	c := m.(*client.Client)
-	err := c.RemoveServiceAccountKey(serviceAccountName, d.Id())
+
+	// Only a single previous key is kept, rotating again within the grace period removes it early
+	keyMissing := isServiceAccountKeyMissing(d.Get("name").(string))
+	rotate := keyMissing || d.HasChange("rotate_when_changed") || isServiceAccountKeyRotationDue(d.Get("created").(string), d.Get("rotation_days").(int), now)
+
+	if previousKeyName != "" && (rotate || isServiceAccountKeyExpired(d.Get("previous_key_expires").(string), now)) {
+
+		if code, err := c.RemoveServiceAccountKey(serviceAccountName, previousKeyName); err != nil && code != 404 {
+			return diag.FromErr(err)
+		}
+
+		if e := setPreviousServiceAccountKey(d, "", ""); e != nil {
+			return e
+		}
+	}
+
+	if !rotate {
+		return nil
+	}
+
+	key, err := c.AddServiceAccountKey(serviceAccountName, d.Get("description").(string))
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if key.Created == nil {
+		key.Created = GetString(now.UTC().Format(time.RFC3339))
+	}
+
+	// A key removed outside of Terraform is only replaced, there is nothing left to keep or remove
+	if keyMissing {
+		return setServiceAccountKey(d, key)
+	}
+
+	// The replaced key becomes the previous key. Without a grace period it expires right away, so if removing it fails
+	// below it is still tracked and its removal is planned again
+	replacedKeyName := d.Id()
+	gracePeriodDays := d.Get("grace_period_days").(int)
+	replacedKeyExpires := now.Add(time.Duration(gracePeriodDays) * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	if e := setServiceAccountKey(d, key); e != nil {
+		return e
+	}
+
+	if e := setPreviousServiceAccountKey(d, replacedKeyName, replacedKeyExpires); e != nil {
+		return e
+	}
+
+	if gracePeriodDays == 0 {
+
+		if code, err := c.RemoveServiceAccountKey(serviceAccountName, replacedKeyName); err != nil && code != 404 {
+			return diag.FromErr(err)
+		}
+
+		return setPreviousServiceAccountKey(d, "", "")
+	}
+
+	return nil
+}
+
+func resourceServiceAccountKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	serviceAccountName := d.Get("service_account_name").(string)
+
+	c := m.(*client.Client)
+
+	// A key that is already gone, or whose service account is, does not fail the destroy
+	if previousKeyName := d.Get("previous_key_name").(string); previousKeyName != "" {
+		if code, err := c.RemoveServiceAccountKey(serviceAccountName, previousKeyName); err != nil && code != 404 {
+			return diag.FromErr(err)
+		}
+	}
+
+	code, err := c.RemoveServiceAccountKey(serviceAccountName, d.Id())
+	if err != nil && code != 404 {
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 
 	return nil
 }
+
+/*** Helpers ***/
+func findServiceAccountKey(keys []client.ServiceAccountKey, keyName string) *client.ServiceAccountKey {
+
+	for _, key := range keys {
+		if key.Name == keyName {
+			found := key
+			return &found
+		}
+	}
+
+	return nil
+}
+
+// getServiceAccountKeyAgeDays - Number of full days since the key was created
+func getServiceAccountKeyAgeDays(created string, now time.Time) (int, error) {
+
+	createdTime, err := time.Parse(time.RFC3339, created)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(now.Sub(createdTime).Hours() / 24), nil
+}
+
+func isServiceAccountKeyRotationDue(created string, rotationDays int, now time.Time) bool {
+
+	if rotationDays < 1 || created == "" {
+		return false
+	}
+
+	ageDays, err := getServiceAccountKeyAgeDays(created, now)
+
+	return err == nil && ageDays >= rotationDays
+}
+
+// isServiceAccountKeyMissing - Read clears the name of a key that was removed outside of Terraform
+func isServiceAccountKeyMissing(name string) bool {
+	return name == ""
+}
+
+func isServiceAccountKeyExpired(expires string, now time.Time) bool {
+
+	expiresTime, err := time.Parse(time.RFC3339, expires)
+
+	return err == nil && !now.Before(expiresTime)
+}
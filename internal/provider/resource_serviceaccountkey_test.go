@@ -0,0 +1,317 @@
+package cpln
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+/*** Unit Tests ***/
+func TestControlPlane_GetServiceAccountKeyAgeDays(t *testing.T) {
+
+	now := time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC)
+
+	ageDays, err := getServiceAccountKeyAgeDays("2024-05-01T10:00:00.000Z", now)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// Less than 30 full days have passed
+	if ageDays != 29 {
+		t.Errorf("Expected an age of 29 days, got: %d", ageDays)
+	}
+
+	if _, err := getServiceAccountKeyAgeDays("not-a-date", now); err == nil {
+		t.Errorf("An invalid creation date should return an error")
+	}
+}
+
+func TestControlPlane_IsServiceAccountKeyRotationDue(t *testing.T) {
+
+	now := time.Date(2024, 5, 31, 10, 0, 0, 0, time.UTC)
+	created := "2024-05-01T10:00:00.000Z"
+
+	cases := []struct {
+		created      string
+		rotationDays int
+		expected     bool
+	}{
+		{created, 0, false},
+		{created, 31, false},
+		{created, 30, true},
+		{created, 7, true},
+		{"", 7, false},
+	}
+
+	for index, testCase := range cases {
+		if isServiceAccountKeyRotationDue(testCase.created, testCase.rotationDays, now) != testCase.expected {
+			t.Errorf("Case %d: expected rotation due to be %t", index, testCase.expected)
+		}
+	}
+}
+
+func TestControlPlane_IsServiceAccountKeyExpired(t *testing.T) {
+
+	now := time.Date(2024, 5, 31, 10, 0, 0, 0, time.UTC)
+
+	if !isServiceAccountKeyExpired("2024-05-31T10:00:00Z", now) {
+		t.Errorf("A key expiring now should be expired")
+	}
+
+	if isServiceAccountKeyExpired("2024-06-01T10:00:00Z", now) {
+		t.Errorf("A key expiring in the future should not be expired")
+	}
+
+	if isServiceAccountKeyExpired("", now) {
+		t.Errorf("A key without an expiration should not be expired")
+	}
+}
+
+func TestControlPlane_FindServiceAccountKey(t *testing.T) {
+
+	keys := *generateTestServiceAccountWithKeys().Keys
+
+	key := findServiceAccountKey(keys, "key-02")
+	expectedKey := &keys[1]
+
+	if diff := deep.Equal(key, expectedKey); diff != nil {
+		t.Errorf("Service account key was not found correctly. Diff: %s", diff)
+	}
+
+	if findServiceAccountKey(keys, "key-03") != nil {
+		t.Errorf("A missing key should not be found")
+	}
+
+	if findServiceAccountKey(nil, "key-01") != nil {
+		t.Errorf("A key should not be found when the service account has no keys")
+	}
+}
+
+func TestControlPlane_ServiceAccountKeyRemovedOutOfBand(t *testing.T) {
+
+	removedKeys := []string{}
+	addedKeys := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		switch r.URL.Path {
+		case "/org/example/serviceaccount/service-account-example/-removeKey":
+
+			body, _ := io.ReadAll(r.Body)
+			request := map[string]string{}
+			json.Unmarshal(body, &request)
+			removedKeys = append(removedKeys, request["name"])
+
+			w.WriteHeader(http.StatusAccepted)
+		case "/org/example/serviceaccount/service-account-example/-addKey":
+			addedKeys++
+			w.Write([]byte(`{"key":"key-03.secret","description":"Key","created":"2024-05-31T10:00:00Z"}`))
+		default:
+			// Only the previous key is left, the current one was removed in the console
+			w.Write([]byte(`{"name":"service-account-example","keys":[{"name":"key-01","description":"Previous key"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client.Client{HostURL: server.URL, Org: "example", HTTPClient: server.Client()}
+
+	previousKeyExpires := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	d := resourceServiceAccountKey().TestResourceData()
+	d.SetId("key-02")
+	d.Set("name", "key-02")
+	d.Set("key", "key-02.secret")
+	d.Set("service_account_name", "service-account-example")
+	d.Set("description", "Key")
+	d.Set("previous_key_name", "key-01")
+	d.Set("previous_key_expires", previousKeyExpires)
+
+	if diags := resourceServiceAccountKeyRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	// Refresh also runs during plan, so it must not change any key
+	if len(removedKeys) != 0 || addedKeys != 0 {
+		t.Errorf("Read should not add or remove keys, removed: %v, added: %d", removedKeys, addedKeys)
+	}
+
+	if d.Id() != "key-02" || d.Get("name").(string) != "" || d.Get("previous_key_name").(string) != "key-01" {
+		t.Errorf("A key removed outside of Terraform should be kept in the state with an empty name and its previous key")
+	}
+
+	// The next plan recreates the key
+	state := d.State()
+
+	diff, err := resourceServiceAccountKey().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"service_account_name": "service-account-example",
+		"description":          "Key",
+	}), nil)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, key := range []string{"name", "key", "previous_key_name"} {
+		if attribute, ok := diff.Attributes[key]; !ok || !attribute.NewComputed {
+			t.Errorf("%s should be unknown when the key was removed outside of Terraform", key)
+		}
+	}
+
+	// Applying it adds a new key and removes the previous one
+	d = resourceServiceAccountKey().Data(state)
+
+	if diags := resourceServiceAccountKeyUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if addedKeys != 1 || d.Id() != "key-03" || d.Get("name").(string) != "key-03" {
+		t.Errorf("A new key should replace the key removed outside of Terraform, got: %s", d.Id())
+	}
+
+	if diff := deep.Equal(removedKeys, []string{"key-01"}); diff != nil {
+		t.Errorf("Only the previous key should be removed when the key is recreated. Diff: %s", diff)
+	}
+
+	if d.Get("previous_key_name").(string) != "" {
+		t.Errorf("The missing key should not be tracked as the previous key")
+	}
+}
+
+func TestControlPlane_UpdateServiceAccountKeyTracksKeysBeforeRemoval(t *testing.T) {
+
+	removeFails := true
+	removedKeys := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		switch r.URL.Path {
+		case "/org/example/serviceaccount/service-account-example/-addKey":
+			w.Write([]byte(`{"key":"key-03.secret","description":"Rotated key","created":"2024-05-31T10:00:00Z"}`))
+		case "/org/example/serviceaccount/service-account-example/-removeKey":
+
+			body, _ := io.ReadAll(r.Body)
+			request := map[string]string{}
+			json.Unmarshal(body, &request)
+
+			if removeFails {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"status":500,"message":"internal error"}`))
+				return
+			}
+
+			removedKeys = append(removedKeys, request["name"])
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &client.Client{HostURL: server.URL, Org: "example", HTTPClient: server.Client()}
+
+	update := func(attributes map[string]string) *schema.ResourceData {
+
+		d := resourceServiceAccountKey().Data(&terraform.InstanceState{ID: attributes["name"], Attributes: attributes})
+
+		if diags := resourceServiceAccountKeyUpdate(context.Background(), d, c); !diags.HasError() {
+			t.Fatalf("The failed key removal should be reported")
+		}
+
+		return d
+	}
+
+	// Without a grace period the replaced key is removed right away, a failure must not lose the new key
+	d := update(map[string]string{
+		"id":                   "key-02",
+		"name":                 "key-02",
+		"key":                  "secret-02",
+		"service_account_name": "service-account-example",
+		"description":          "Rotated key",
+		"created":              "2024-01-01T10:00:00Z",
+		"rotation_days":        "30",
+		"grace_period_days":    "0",
+	})
+
+	if d.Id() != "key-03" || d.Get("key").(string) != "key-03.secret" {
+		t.Errorf("The new key should be tracked when removing the replaced key fails, got: %s", d.Id())
+	}
+
+	if d.Get("previous_key_name").(string) != "key-02" || !isServiceAccountKeyExpired(d.Get("previous_key_expires").(string), time.Now()) {
+		t.Errorf("The replaced key should be tracked as an expired previous key so its removal is planned again")
+	}
+
+	// A previous key that cannot be removed before rotating is kept, as is the current key
+	d = update(map[string]string{
+		"id":                   "key-02",
+		"name":                 "key-02",
+		"key":                  "secret-02",
+		"service_account_name": "service-account-example",
+		"description":          "Rotated key",
+		"created":              "2024-01-01T10:00:00Z",
+		"rotation_days":        "30",
+		"grace_period_days":    "1",
+		"previous_key_name":    "key-01",
+		"previous_key_expires": "2030-01-01T10:00:00Z",
+	})
+
+	if d.Id() != "key-02" || d.Get("key").(string) != "secret-02" || d.Get("previous_key_name").(string) != "key-01" {
+		t.Errorf("The current and previous keys should be kept when removing the previous key fails")
+	}
+
+	removeFails = false
+
+	d = resourceServiceAccountKey().Data(&terraform.InstanceState{ID: "key-02", Attributes: map[string]string{
+		"id":                   "key-02",
+		"name":                 "key-02",
+		"key":                  "secret-02",
+		"service_account_name": "service-account-example",
+		"description":          "Rotated key",
+		"created":              "2024-01-01T10:00:00Z",
+		"rotation_days":        "30",
+		"grace_period_days":    "1",
+		"previous_key_name":    "key-01",
+		"previous_key_expires": "2030-01-01T10:00:00Z",
+	}})
+
+	if diags := resourceServiceAccountKeyUpdate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	if d.Id() != "key-03" || d.Get("previous_key_name").(string) != "key-02" {
+		t.Errorf("The rotated key should replace the current one and keep it as the previous key, got: %s / %s", d.Id(), d.Get("previous_key_name"))
+	}
+
+	if diff := deep.Equal(removedKeys, []string{"key-01"}); diff != nil {
+		t.Errorf("Only the former previous key should be removed. Diff: %s", diff)
+	}
+}
+
+/*** Generate ***/
+func generateTestServiceAccountWithKeys() *client.ServiceAccount {
+
+	return &client.ServiceAccount{
+		Keys: &[]client.ServiceAccountKey{
+			{
+				Name:        "key-01",
+				Created:     GetString("2024-04-01T10:00:00.000Z"),
+				Description: GetString("Previous key"),
+			},
+			{
+				Name:        "key-02",
+				Created:     GetString("2024-05-01T10:00:00.000Z"),
+				Description: GetString("Current key"),
+			},
+		},
+	}
+}
@@ -2,9 +2,9 @@ package cpln
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
 
@@ -15,10 +15,19 @@ import (
 /*** Main ***/
 func resourceVolumeSet() *schema.Resource {
 	return &schema.Resource{
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceVolumeSetV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceVolumeSetStateUpgradeV0,
+			},
+		},
 		CreateContext: resourceVolumeSetCreate,
 		ReadContext:   resourceVolumeSetRead,
 		UpdateContext: resourceVolumeSetUpdate,
 		DeleteContext: resourceVolumeSetDelete,
+		CustomizeDiff: customizeDiffVolumeSet,
 		Schema: map[string]*schema.Schema{
 			"cpln_id": {
 				Type:     schema.TypeString,
@@ -66,9 +75,9 @@ func resourceVolumeSet() *schema.Resource {
 							Computed: true,
 						},
 						"locations": {
-							Type:     schema.TypeSet,
-							Elem:     &schema.Schema{Type: schema.TypeString},
+							Type:     schema.TypeList,
 							Computed: true,
+							Elem:     volumeSetStatusLocationResource(),
 						},
 					},
 				},
@@ -146,6 +155,215 @@ func resourceVolumeSet() *schema.Resource {
 	}
 }
 
+func volumeSetStatusLocationResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"lifecycle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_device_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"current_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"current_bytes_used": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"iops": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"throughput": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"snapshots": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"created": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"expires": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"size": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceVolumeSetV0 - Schema before the status locations were typed, when each location was stored as a JSON string
+func resourceVolumeSetV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"cpln_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"self_link": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"parent_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"used_by_workload": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"binding_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"locations": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Computed: true,
+						},
+					},
+				},
+			},
+			"gvc": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"initial_capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"performance_class": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"file_system_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"snapshots": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"create_final_snapshot": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"retention_duration": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"schedule": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"autoscaling": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_capacity": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"min_free_percentage": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"scaling_factor": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceVolumeSetStateUpgradeV0 - The computed status is dropped, it is read again with typed locations on the next refresh
+func resourceVolumeSetStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	delete(rawState, "status")
+
+	return rawState, nil
+}
+
 func importStateVolumeSet(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 	parts := strings.SplitN(d.Id(), ":", 2)
@@ -160,6 +378,59 @@ func importStateVolumeSet(ctx context.Context, d *schema.ResourceData, meta inte
 	return []*schema.ResourceData{d}, nil
 }
 
+// volumeSetPerformanceClasses - Capacity limits, in GB, of each performance class
+var volumeSetPerformanceClasses = map[string]struct {
+	MinCapacity int
+	MaxCapacity int
+}{
+	"general-purpose-ssd": {MinCapacity: 10, MaxCapacity: 65536},
+	"high-throughput-ssd": {MinCapacity: 200, MaxCapacity: 65536},
+}
+
+func customizeDiffVolumeSet(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+
+	if !diff.NewValueKnown("initial_capacity") || !diff.NewValueKnown("performance_class") {
+		return nil
+	}
+
+	oldCapacity, newCapacity := diff.GetChange("initial_capacity")
+	maxCapacity := 0
+
+	if autoscaling := diff.Get("autoscaling").([]interface{}); len(autoscaling) > 0 && autoscaling[0] != nil {
+		maxCapacity = autoscaling[0].(map[string]interface{})["max_capacity"].(int)
+	}
+
+	// A change to a ForceNew attribute replaces the volume set, the new volumes can be of any capacity
+	isNew := diff.Id() == "" || diff.HasChanges("name", "gvc", "performance_class", "file_system_type")
+
+	return validateVolumeSetCapacity(diff.Get("performance_class").(string), isNew, oldCapacity.(int), newCapacity.(int), maxCapacity)
+}
+
+// validateVolumeSetCapacity - Volumes can only grow in place, and must stay within the limits of their performance class
+func validateVolumeSetCapacity(performanceClass string, isNew bool, oldCapacity int, newCapacity int, maxCapacity int) error {
+
+	if !isNew && newCapacity < oldCapacity {
+		return fmt.Errorf("initial_capacity cannot be decreased from %d to %d, volumes can only be expanded. To shrink the volumes, create a new volume set and migrate the data", oldCapacity, newCapacity)
+	}
+
+	if limits, ok := volumeSetPerformanceClasses[performanceClass]; ok {
+
+		if newCapacity < limits.MinCapacity || newCapacity > limits.MaxCapacity {
+			return fmt.Errorf("initial_capacity must be between %d and %d GB for performance class '%s', got: %d", limits.MinCapacity, limits.MaxCapacity, performanceClass, newCapacity)
+		}
+
+		if maxCapacity > limits.MaxCapacity {
+			return fmt.Errorf("autoscaling max_capacity cannot be greater than %d GB for performance class '%s', got: %d", limits.MaxCapacity, performanceClass, maxCapacity)
+		}
+	}
+
+	if maxCapacity != 0 && maxCapacity < newCapacity {
+		return fmt.Errorf("autoscaling max_capacity (%d) must be greater than or equal to initial_capacity (%d)", maxCapacity, newCapacity)
+	}
+
+	return nil
+}
+
 func setVolumeSet(d *schema.ResourceData, volumeSet *client.VolumeSet) diag.Diagnostics {
 
 	if volumeSet == nil {
@@ -301,7 +572,42 @@ func resourceVolumeSetUpdate(ctx context.Context, d *schema.ResourceData, m inte
 			return diag.FromErr(err)
 		}
 
-		return setVolumeSet(d, updatedVolumeSet)
+		var diags diag.Diagnostics
+
+		// Growing the capacity expands the existing volumes online, wait until every volume reports the new size
+		if oldCapacity, newCapacity := d.GetChange("initial_capacity"); newCapacity.(int) > oldCapacity.(int) {
+
+			count := 0
+
+			for !isVolumeSetExpanded(updatedVolumeSet, newCapacity.(int)) {
+
+				if count++; count > 8 {
+					// Exit loop after 120 seconds
+
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Warning,
+						Summary:  "Volume expansion is still in progress",
+						Detail:   fmt.Sprintf("Not every volume of volume set '%s' reports a size of %d GB yet. Run 'terraform refresh' to check the status.", *volumeSetToUpdate.Name, newCapacity.(int)),
+					})
+
+					break
+				}
+
+				time.Sleep(15 * time.Second)
+
+				updatedVolumeSet, _, err = c.GetVolumeSet(*volumeSetToUpdate.Name, d.Get("gvc").(string))
+
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
+
+		if e := setVolumeSet(d, updatedVolumeSet); e != nil {
+			return e
+		}
+
+		return diags
 	}
 
 	return nil
@@ -321,6 +627,29 @@ func resourceVolumeSetDelete(ctx context.Context, d *schema.ResourceData, m inte
 	return nil
 }
 
+// isVolumeSetExpanded - Every volume of every location reports at least the given size in GB
+func isVolumeSetExpanded(volumeSet *client.VolumeSet, capacity int) bool {
+
+	if volumeSet == nil || volumeSet.Status == nil || volumeSet.Status.Locations == nil {
+		return true
+	}
+
+	for _, location := range *volumeSet.Status.Locations {
+
+		if location.Volumes == nil {
+			continue
+		}
+
+		for _, volume := range *location.Volumes {
+			if volume.CurrentSize != nil && *volume.CurrentSize < capacity {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 /*** Build ***/
 func buildVolumeSetSnapshots(specs []interface{}) *client.VolumeSetSnapshots {
 
@@ -396,23 +725,118 @@ func flattenVolumeSetStatus(status *client.VolumeSetStatus) []interface{} {
 	}
 }
 
-func flattenVolumeSetStatusLocations(locations *[]interface{}) []interface{} {
+func flattenVolumeSetStatusLocations(locations *[]client.VolumeSetLocation) []interface{} {
+
+	if locations == nil {
+		return nil
+	}
+
+	output := []interface{}{}
+
+	for _, location := range *locations {
+
+		l := map[string]interface{}{}
+
+		if location.Name != nil {
+			l["name"] = *location.Name
+		}
+
+		if location.Volumes != nil {
+			l["volumes"] = flattenVolumeSetStatusVolumes(location.Volumes)
+		}
+
+		output = append(output, l)
+	}
+
+	return output
+}
+
+func flattenVolumeSetStatusVolumes(volumes *[]client.VolumeSetVolume) []interface{} {
 
-	result := make([]interface{}, len(*locations))
+	output := []interface{}{}
 
-	for i, location := range *locations {
+	for _, volume := range *volumes {
 
-		jsonData, err := json.Marshal(location)
+		v := map[string]interface{}{}
 
-		if err != nil {
-			result[i] = fmt.Sprintf("Error serializing to JSON: %s", err)
+		if volume.Index != nil {
+			v["index"] = *volume.Index
+		}
+
+		if volume.Lifecycle != nil {
+			v["lifecycle"] = *volume.Lifecycle
+		}
 
-		} else {
-			result[i] = string(jsonData)
+		if volume.StorageDeviceId != nil {
+			v["storage_device_id"] = *volume.StorageDeviceId
 		}
+
+		if volume.ResourceName != nil {
+			v["resource_name"] = *volume.ResourceName
+		}
+
+		if volume.Zone != nil {
+			v["zone"] = *volume.Zone
+		}
+
+		if volume.CurrentSize != nil {
+			v["current_size"] = *volume.CurrentSize
+		}
+
+		if volume.CurrentBytesUsed != nil {
+			v["current_bytes_used"] = int(*volume.CurrentBytesUsed)
+		}
+
+		if volume.Iops != nil {
+			v["iops"] = *volume.Iops
+		}
+
+		if volume.Throughput != nil {
+			v["throughput"] = *volume.Throughput
+		}
+
+		if volume.VolumeSnapshots != nil {
+			v["snapshots"] = flattenVolumeSetStatusSnapshots(volume.VolumeSnapshots)
+		}
+
+		output = append(output, v)
+	}
+
+	return output
+}
+
+func flattenVolumeSetStatusSnapshots(snapshots *[]client.VolumeSetVolumeSnapshot) []interface{} {
+
+	output := []interface{}{}
+
+	for _, snapshot := range *snapshots {
+
+		s := map[string]interface{}{}
+
+		if snapshot.Id != nil {
+			s["id"] = *snapshot.Id
+		}
+
+		if snapshot.Name != nil {
+			s["name"] = *snapshot.Name
+		}
+
+		if snapshot.Created != nil {
+			s["created"] = *snapshot.Created
+		}
+
+		if snapshot.Expires != nil {
+			s["expires"] = *snapshot.Expires
+		}
+
+		if snapshot.Size != nil {
+			s["size"] = *snapshot.Size
+		}
+
+		output = append(output, s)
 	}
 
-	return result
+	return output
 }
 
 func flattenVolumeSetSnapshots(snapshots *client.VolumeSetSnapshots) []interface{} {
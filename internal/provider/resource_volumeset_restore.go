@@ -0,0 +1,128 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Main ***/
+
+// resourceVolumeSetRestore - Restores a volume from a snapshot when created. Any change to its arguments replaces it, which restores again
+func resourceVolumeSetRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVolumeSetRestoreCreate,
+		ReadContext:   resourceVolumeSetRestoreRead,
+		DeleteContext: resourceVolumeSetRestoreDelete,
+		Schema: map[string]*schema.Schema{
+			"gvc": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"volume_set": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_index": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  0,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(int)
+
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q must be >= 0, got: %d", key, v))
+					}
+
+					return
+				},
+			},
+			"snapshot_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceVolumeSetRestoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	volumeSetName := d.Get("volume_set").(string)
+	location := d.Get("location").(string)
+	volumeIndex := d.Get("volume_index").(int)
+	snapshotName := d.Get("snapshot_name").(string)
+
+	c := m.(*client.Client)
+	volumeSet, code, err := c.GetVolumeSet(volumeSetName, gvcName)
+
+	if code == 404 {
+		return diag.FromErr(fmt.Errorf("volume set '%s' does not exist in gvc '%s'", volumeSetName, gvcName))
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if findVolumeSetSnapshot(volumeSet, location, volumeIndex, snapshotName) == nil {
+		return diag.FromErr(fmt.Errorf("snapshot '%s' does not exist for volume %d of volume set '%s' in location '%s'", snapshotName, volumeIndex, volumeSetName, location))
+	}
+
+	if _, err := c.RestoreVolumeSetVolume(volumeSetName, gvcName, buildVolumeSetCommandVolume(location, volumeIndex, snapshotName)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(volumeSetSnapshotId(volumeSetName, location, volumeIndex, snapshotName))
+
+	return nil
+}
+
+func resourceVolumeSetRestoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	_, code, err := c.GetVolumeSet(d.Get("volume_set").(string), d.Get("gvc").(string))
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVolumeSetRestoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// A restore cannot be undone, destroying only removes it from the state
+	d.SetId("")
+
+	return nil
+}
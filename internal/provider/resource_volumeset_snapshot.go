@@ -0,0 +1,367 @@
+package cpln
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// volumeSetSnapshotPendingTimeout - How long a requested snapshot may go unreported by its volume before it is considered failed
+const volumeSetSnapshotPendingTimeout = time.Hour
+
+/*** Main ***/
+func resourceVolumeSetSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVolumeSetSnapshotCreate,
+		ReadContext:   resourceVolumeSetSnapshotRead,
+		DeleteContext: resourceVolumeSetSnapshotDelete,
+		Schema: map[string]*schema.Schema{
+			"gvc": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"volume_set": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_index": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+				Default:  0,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(int)
+
+					if v < 0 {
+						errs = append(errs, fmt.Errorf("%q must be >= 0, got: %d", key, v))
+					}
+
+					return
+				},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: NameValidator,
+			},
+			"expiration_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(string)
+
+					if _, err := time.Parse(time.RFC3339, v); err != nil {
+						errs = append(errs, fmt.Errorf("%q must be an RFC 3339 date (e.g. 2030-01-01T00:00:00Z), got: %s", key, v))
+					}
+
+					return
+				},
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateFunc: TagValidator,
+			},
+			"requested_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateVolumeSetSnapshot,
+		},
+	}
+}
+
+func importStateVolumeSetSnapshot(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	parts := strings.SplitN(d.Id(), ":", 5)
+
+	if len(parts) != 5 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" || parts[4] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected ID syntax: 'gvc:volume_set:location:volume_index:name'. Example: 'terraform import cpln_volume_set_snapshot.RESOURCE_NAME GVC_NAME:VOLUME_SET_NAME:LOCATION:VOLUME_INDEX:SNAPSHOT_NAME'", d.Id())
+	}
+
+	volumeIndex, err := strconv.Atoi(parts[3])
+
+	if err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), volume index is invalid, must be a integer. value provided: %s. error: %s", d.Id(), parts[3], err.Error())
+	}
+
+	c := meta.(*client.Client)
+	volumeSet, _, err := c.GetVolumeSet(parts[1], parts[0])
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Read keeps snapshots that were never reported, make sure the imported one exists
+	if findVolumeSetSnapshot(volumeSet, parts[2], volumeIndex, parts[4]) == nil {
+		return nil, fmt.Errorf("snapshot '%s' was not found on volume %d of volume set '%s' in location '%s'", parts[4], volumeIndex, parts[1], parts[2])
+	}
+
+	d.Set("gvc", parts[0])
+	d.Set("volume_set", parts[1])
+	d.Set("location", parts[2])
+	d.Set("volume_index", volumeIndex)
+	d.Set("name", parts[4])
+	d.SetId(volumeSetSnapshotId(parts[1], parts[2], volumeIndex, parts[4]))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceVolumeSetSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	gvcName := d.Get("gvc").(string)
+	volumeSetName := d.Get("volume_set").(string)
+	location := d.Get("location").(string)
+	volumeIndex := d.Get("volume_index").(int)
+	snapshotName := d.Get("name").(string)
+
+	volume := buildVolumeSetCommandVolume(location, volumeIndex, snapshotName)
+	volume.SnapshotExpirationDate = GetString(d.Get("expiration_date"))
+
+	if tags := GetStringMap(d.Get("tags")); tags != nil && len(*tags) > 0 {
+
+		snapshotTags := map[string]string{}
+
+		for key, value := range *tags {
+			snapshotTags[key] = fmt.Sprintf("%v", value)
+		}
+
+		volume.SnapshotTags = &snapshotTags
+	}
+
+	c := m.(*client.Client)
+	code, err := c.CreateVolumeSetSnapshot(volumeSetName, gvcName, volume)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(volumeSetSnapshotId(volumeSetName, location, volumeIndex, snapshotName))
+
+	if err := d.Set("requested_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	count := 0
+
+	// Snapshots are taken asynchronously, wait for the snapshot to be reported by the volume
+	for {
+
+		volumeSet, _, err := c.GetVolumeSet(volumeSetName, gvcName)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if snapshot := findVolumeSetSnapshot(volumeSet, location, volumeIndex, snapshotName); snapshot != nil {
+			return setVolumeSetSnapshot(d, snapshot, diags)
+		}
+
+		if count++; count > 8 {
+			// Exit loop after 120 seconds
+
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unable to obtain snapshot status",
+				Detail:   fmt.Sprintf("Snapshot '%s' has not been reported by volume %d of volume set '%s' in location '%s' yet. It is kept in the state and its attributes will be populated by a later 'terraform refresh'. It is removed from the state if it is still not reported after %s.", snapshotName, volumeIndex, volumeSetName, location, volumeSetSnapshotPendingTimeout),
+			})
+
+			return diags
+		}
+
+		time.Sleep(15 * time.Second)
+	}
+}
+
+func resourceVolumeSetSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	c := m.(*client.Client)
+	volumeSet, code, err := c.GetVolumeSet(d.Get("volume_set").(string), d.Get("gvc").(string))
+
+	if code == 404 {
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshot := findVolumeSetSnapshot(volumeSet, d.Get("location").(string), d.Get("volume_index").(int), d.Get("name").(string))
+
+	if snapshot == nil {
+
+		// A snapshot that was reported by the volume and is now missing is gone
+		if d.Get("snapshot_id").(string) != "" {
+			d.SetId("")
+			return nil
+		}
+
+		// A snapshot that was never reported is still being taken, unless it was requested too long ago
+		requestedAt, err := time.Parse(time.RFC3339, d.Get("requested_at").(string))
+
+		if err != nil {
+
+			// Start the wait for snapshots tracked before the request time was stored
+			if err := d.Set("requested_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+				return diag.FromErr(err)
+			}
+
+			return nil
+		}
+
+		if time.Since(requestedAt) > volumeSetSnapshotPendingTimeout {
+
+			d.SetId("")
+
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "Snapshot was never reported",
+					Detail:   fmt.Sprintf("Snapshot '%s' was requested at %s and has not been reported by volume %d of volume set '%s' in location '%s' within %s. It is assumed to have failed and is removed from the state.", d.Get("name").(string), requestedAt.Format(time.RFC3339), d.Get("volume_index").(int), d.Get("volume_set").(string), d.Get("location").(string), volumeSetSnapshotPendingTimeout),
+				},
+			}
+		}
+
+		return nil
+	}
+
+	return setVolumeSetSnapshot(d, snapshot, nil)
+}
+
+func resourceVolumeSetSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	volume := buildVolumeSetCommandVolume(d.Get("location").(string), d.Get("volume_index").(int), d.Get("name").(string))
+
+	c := m.(*client.Client)
+	code, err := c.DeleteVolumeSetSnapshot(d.Get("volume_set").(string), d.Get("gvc").(string), volume)
+
+	if err != nil && code != 404 {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setVolumeSetSnapshot(d *schema.ResourceData, snapshot *client.VolumeSetVolumeSnapshot, diags diag.Diagnostics) diag.Diagnostics {
+
+	if snapshot.Id != nil {
+		if err := d.Set("snapshot_id", *snapshot.Id); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if snapshot.Size != nil {
+		if err := d.Set("size", *snapshot.Size); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if snapshot.Created != nil {
+		if err := d.Set("created", *snapshot.Created); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if snapshot.Expires != nil {
+		if err := d.Set("expires", *snapshot.Expires); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+/*** Build ***/
+func buildVolumeSetCommandVolume(location string, volumeIndex int, snapshotName string) client.VolumeSetCommandVolume {
+	return client.VolumeSetCommandVolume{
+		Location:     GetString(location),
+		VolumeIndex:  &volumeIndex,
+		SnapshotName: GetString(snapshotName),
+	}
+}
+
+/*** Helpers ***/
+func volumeSetSnapshotId(volumeSetName string, location string, volumeIndex int, snapshotName string) string {
+	return fmt.Sprintf("%s:%s:%d:%s", volumeSetName, location, volumeIndex, snapshotName)
+}
+
+// findVolumeSetSnapshot - Look up a snapshot by name in the status of the volume at the given location and index
+func findVolumeSetSnapshot(volumeSet *client.VolumeSet, location string, volumeIndex int, snapshotName string) *client.VolumeSetVolumeSnapshot {
+
+	if volumeSet == nil || volumeSet.Status == nil || volumeSet.Status.Locations == nil {
+		return nil
+	}
+
+	for _, l := range *volumeSet.Status.Locations {
+
+		if l.Name == nil || GetNameFromSelfLink(*l.Name) != location || l.Volumes == nil {
+			continue
+		}
+
+		for _, volume := range *l.Volumes {
+
+			if volume.Index == nil || *volume.Index != volumeIndex || volume.VolumeSnapshots == nil {
+				continue
+			}
+
+			for _, snapshot := range *volume.VolumeSnapshots {
+				if snapshot.Name != nil && *snapshot.Name == snapshotName {
+					found := snapshot
+					return &found
+				}
+			}
+		}
+	}
+
+	return nil
+}
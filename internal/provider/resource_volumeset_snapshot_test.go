@@ -0,0 +1,234 @@
+package cpln
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*** Unit Tests ***/
+func TestControlPlane_FindVolumeSetSnapshot(t *testing.T) {
+
+	volumeSet := generateTestVolumeSetWithSnapshots()
+
+	snapshot := findVolumeSetSnapshot(volumeSet, "aws-eu-central-1", 1, "before-migration")
+	expectedSnapshot := &client.VolumeSetVolumeSnapshot{
+		Id:      GetString("snap-0b1c2d3e"),
+		Name:    GetString("before-migration"),
+		Created: GetString("2024-05-01T10:00:00.000Z"),
+		Expires: GetString("2024-06-01T10:00:00.000Z"),
+		Size:    GetInt(10),
+	}
+
+	if diff := deep.Equal(snapshot, expectedSnapshot); diff != nil {
+		t.Errorf("Volume set snapshot was not found correctly. Diff: %s", diff)
+	}
+
+	if findVolumeSetSnapshot(volumeSet, "aws-eu-central-1", 0, "before-migration") != nil {
+		t.Errorf("A snapshot of a different volume index should not be found")
+	}
+
+	if findVolumeSetSnapshot(volumeSet, "aws-us-west-2", 1, "before-migration") != nil {
+		t.Errorf("A snapshot in a different location should not be found")
+	}
+
+	if findVolumeSetSnapshot(&client.VolumeSet{}, "aws-eu-central-1", 1, "before-migration") != nil {
+		t.Errorf("A snapshot should not be found when the volume set has no status")
+	}
+}
+
+func TestControlPlane_ReadVolumeSetSnapshot(t *testing.T) {
+
+	volumeSetFound := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if !volumeSetFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(generateTestVolumeSetWithSnapshots())
+	}))
+	defer server.Close()
+
+	c := &client.Client{
+		HostURL:    server.URL,
+		Org:        "unit-test-org",
+		HTTPClient: server.Client(),
+	}
+
+	read := func(snapshotName string, snapshotId string, requestedAt string) string {
+
+		d := schema.TestResourceDataRaw(t, resourceVolumeSetSnapshot().Schema, map[string]interface{}{
+			"gvc":          "gvc-01",
+			"volume_set":   "volume-set-01",
+			"location":     "aws-eu-central-1",
+			"volume_index": 1,
+			"name":         snapshotName,
+		})
+
+		d.SetId(volumeSetSnapshotId("volume-set-01", "aws-eu-central-1", 1, snapshotName))
+		d.Set("snapshot_id", snapshotId)
+		d.Set("requested_at", requestedAt)
+
+		if diags := resourceVolumeSetSnapshotRead(context.Background(), d, c); diags.HasError() {
+			t.Fatalf("Unexpected error reading snapshot '%s': %v", snapshotName, diags)
+		}
+
+		return d.Id()
+	}
+
+	recent := time.Now().UTC().Add(-time.Minute).Format(time.RFC3339)
+	expired := time.Now().UTC().Add(-2 * volumeSetSnapshotPendingTimeout).Format(time.RFC3339)
+
+	if read("before-migration", "", recent) == "" || read("pending", "", recent) == "" {
+		t.Errorf("A reported snapshot and a snapshot still being taken should be kept in the state")
+	}
+
+	if read("pending", "", "") == "" {
+		t.Errorf("A pending snapshot without a request time should be kept in the state")
+	}
+
+	if read("pending", "", expired) != "" {
+		t.Errorf("A snapshot that was not reported within the pending timeout should be removed from the state")
+	}
+
+	if read("removed", "snap-0c1d2e3f", recent) != "" {
+		t.Errorf("A snapshot that was reported and is now gone should be removed from the state")
+	}
+
+	volumeSetFound = false
+
+	if read("pending", "", recent) != "" {
+		t.Errorf("A snapshot should be removed from the state when its volume set is gone")
+	}
+}
+
+func TestControlPlane_CreateVolumeSetRestore(t *testing.T) {
+
+	var commands []map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method == http.MethodPost {
+			command := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&command)
+			commands = append(commands, command)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
+		json.NewEncoder(w).Encode(generateTestVolumeSetWithSnapshots())
+	}))
+	defer server.Close()
+
+	c := &client.Client{
+		HostURL:    server.URL,
+		Org:        "unit-test-org",
+		HTTPClient: server.Client(),
+	}
+
+	restore := func(snapshotName string) (*schema.ResourceData, diag.Diagnostics) {
+
+		d := schema.TestResourceDataRaw(t, resourceVolumeSetRestore().Schema, map[string]interface{}{
+			"gvc":           "gvc-01",
+			"volume_set":    "volume-set-01",
+			"location":      "aws-eu-central-1",
+			"volume_index":  1,
+			"snapshot_name": snapshotName,
+		})
+
+		return d, resourceVolumeSetRestoreCreate(context.Background(), d, c)
+	}
+
+	d, diags := restore("missing")
+
+	if !diags.HasError() || d.Id() != "" {
+		t.Errorf("Restoring from a snapshot that does not exist should fail without tracking the restore")
+	}
+
+	if len(commands) != 0 {
+		t.Fatalf("No restore should be posted when the snapshot does not exist, got: %v", commands)
+	}
+
+	d, diags = restore("before-migration")
+
+	if diags.HasError() {
+		t.Fatalf("Unexpected error restoring the snapshot: %v", diags)
+	}
+
+	if d.Id() != volumeSetSnapshotId("volume-set-01", "aws-eu-central-1", 1, "before-migration") {
+		t.Errorf("Restore ID was not set correctly, got: %s", d.Id())
+	}
+
+	expectedCommands := []map[string]interface{}{
+		{
+			"type": "restoreVolume",
+			"spec": map[string]interface{}{
+				"location":     "aws-eu-central-1",
+				"volumeIndex":  float64(1),
+				"snapshotName": "before-migration",
+			},
+		},
+	}
+
+	if diff := deep.Equal(commands, expectedCommands); diff != nil {
+		t.Errorf("Restore command was not posted correctly. Diff: %s", diff)
+	}
+}
+
+/*** Generate ***/
+func generateTestVolumeSetWithSnapshots() *client.VolumeSet {
+
+	return &client.VolumeSet{
+		Status: &client.VolumeSetStatus{
+			Locations: &[]client.VolumeSetLocation{
+				{
+					Name: GetString("aws-eu-central-1"),
+					Volumes: &[]client.VolumeSetVolume{
+						{
+							Index: GetInt(0),
+						},
+						{
+							Index: GetInt(1),
+							VolumeSnapshots: &[]client.VolumeSetVolumeSnapshot{
+								{
+									Id:      GetString("snap-0a1b2c3d"),
+									Name:    GetString("nightly"),
+									Created: GetString("2024-04-30T02:00:00.000Z"),
+									Expires: GetString("2024-05-30T02:00:00.000Z"),
+									Size:    GetInt(10),
+								},
+								{
+									Id:      GetString("snap-0b1c2d3e"),
+									Name:    GetString("before-migration"),
+									Created: GetString("2024-05-01T10:00:00.000Z"),
+									Expires: GetString("2024-06-01T10:00:00.000Z"),
+									Size:    GetInt(10),
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: GetString("aws-us-west-2"),
+					Volumes: &[]client.VolumeSetVolume{
+						{
+							Index: GetInt(1),
+						},
+					},
+				},
+			},
+		},
+	}
+}
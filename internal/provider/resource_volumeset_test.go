@@ -1,6 +1,7 @@
 package cpln
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -404,6 +405,171 @@ func TestControlPlane_FlattenVolumeSetAutoscaling(t *testing.T) {
 	}
 }
 
+func TestControlPlane_FlattenVolumeSetStatusLocations(t *testing.T) {
+
+	volumeSet := generateTestVolumeSetWithSnapshots()
+	(*(*volumeSet.Status.Locations)[0].Volumes)[1].Lifecycle = GetString("bound")
+	(*(*volumeSet.Status.Locations)[0].Volumes)[1].Zone = GetString("eu-central-1a")
+	(*(*volumeSet.Status.Locations)[0].Volumes)[1].CurrentSize = GetInt(20)
+	(*(*volumeSet.Status.Locations)[0].Volumes)[1].CurrentBytesUsed = &[]int64{5368709120}[0]
+
+	flattenedLocations := flattenVolumeSetStatusLocations(volumeSet.Status.Locations)
+	expectedLocations := []interface{}{
+		map[string]interface{}{
+			"name": "aws-eu-central-1",
+			"volumes": []interface{}{
+				map[string]interface{}{
+					"index": 0,
+				},
+				map[string]interface{}{
+					"index":              1,
+					"lifecycle":          "bound",
+					"zone":               "eu-central-1a",
+					"current_size":       20,
+					"current_bytes_used": 5368709120,
+					"snapshots": []interface{}{
+						map[string]interface{}{
+							"id":      "snap-0a1b2c3d",
+							"name":    "nightly",
+							"created": "2024-04-30T02:00:00.000Z",
+							"expires": "2024-05-30T02:00:00.000Z",
+							"size":    10,
+						},
+						map[string]interface{}{
+							"id":      "snap-0b1c2d3e",
+							"name":    "before-migration",
+							"created": "2024-05-01T10:00:00.000Z",
+							"expires": "2024-06-01T10:00:00.000Z",
+							"size":    10,
+						},
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"name": "aws-us-west-2",
+			"volumes": []interface{}{
+				map[string]interface{}{
+					"index": 1,
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(expectedLocations, flattenedLocations); diff != nil {
+		t.Errorf("Volume set status locations were not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_VolumeSetStateUpgradeV0(t *testing.T) {
+
+	rawState := map[string]interface{}{
+		"name": "data",
+		"status": []interface{}{
+			map[string]interface{}{
+				"parent_id": "gvc-id",
+				"locations": []interface{}{`{"name":"aws-eu-central-1"}`},
+			},
+		},
+	}
+
+	upgradedState, err := resourceVolumeSetStateUpgradeV0(context.Background(), rawState, nil)
+
+	if err != nil {
+		t.Fatalf("Volume set state upgrade failed: %s", err)
+	}
+
+	expectedState := map[string]interface{}{
+		"name": "data",
+	}
+
+	if diff := deep.Equal(expectedState, upgradedState); diff != nil {
+		t.Errorf("Volume set state was not upgraded correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_ValidateVolumeSetCapacity(t *testing.T) {
+
+	if err := validateVolumeSetCapacity("general-purpose-ssd", true, 0, 10, 0); err != nil {
+		t.Errorf("A new volume set with the minimum capacity should be valid, got: %s", err)
+	}
+
+	if err := validateVolumeSetCapacity("general-purpose-ssd", false, 10, 20, 100); err != nil {
+		t.Errorf("Growing a volume set should be valid, got: %s", err)
+	}
+
+	if err := validateVolumeSetCapacity("general-purpose-ssd", false, 20, 10, 0); err == nil {
+		t.Errorf("Shrinking a volume set should not be allowed")
+	}
+
+	if err := validateVolumeSetCapacity("high-throughput-ssd", true, 0, 100, 0); err == nil {
+		t.Errorf("A high throughput volume set below its minimum capacity should not be allowed")
+	}
+
+	if err := validateVolumeSetCapacity("general-purpose-ssd", true, 0, 100, 50); err == nil {
+		t.Errorf("Autoscaling max capacity below the initial capacity should not be allowed")
+	}
+
+	if err := validateVolumeSetCapacity("general-purpose-ssd", true, 0, 100, 100000); err == nil {
+		t.Errorf("Autoscaling max capacity above the performance class limit should not be allowed")
+	}
+}
+
+func TestControlPlane_CustomizeDiffVolumeSetCapacity(t *testing.T) {
+
+	state := &terraform.InstanceState{
+		ID: "volume-set-01",
+		Attributes: map[string]string{
+			"id":                "volume-set-01",
+			"name":              "volume-set-01",
+			"gvc":               "gvc-01",
+			"initial_capacity":  "500",
+			"performance_class": "general-purpose-ssd",
+			"file_system_type":  "ext4",
+		},
+	}
+
+	diff := func(performanceClass string, initialCapacity int) error {
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":              "volume-set-01",
+			"gvc":               "gvc-01",
+			"initial_capacity":  initialCapacity,
+			"performance_class": performanceClass,
+			"file_system_type":  "ext4",
+		})
+
+		_, err := resourceVolumeSet().SimpleDiff(context.Background(), state, config, nil)
+
+		return err
+	}
+
+	if err := diff("general-purpose-ssd", 100); err == nil {
+		t.Errorf("Shrinking a volume set in place should not be allowed")
+	}
+
+	if err := diff("high-throughput-ssd", 300); err != nil {
+		t.Errorf("Shrinking should be allowed when the performance class change replaces the volume set, got: %s", err)
+	}
+}
+
+func TestControlPlane_IsVolumeSetExpanded(t *testing.T) {
+
+	volumeSet := generateTestVolumeSetWithSnapshots()
+	(*(*volumeSet.Status.Locations)[0].Volumes)[0].CurrentSize = GetInt(20)
+	(*(*volumeSet.Status.Locations)[0].Volumes)[1].CurrentSize = GetInt(10)
+
+	if isVolumeSetExpanded(volumeSet, 20) {
+		t.Errorf("A volume set should not be expanded while one of its volumes reports a smaller size")
+	}
+
+	(*(*volumeSet.Status.Locations)[0].Volumes)[1].CurrentSize = GetInt(20)
+
+	if !isVolumeSetExpanded(volumeSet, 20) {
+		t.Errorf("A volume set should be expanded once every volume reports the new size")
+	}
+}
+
 /*** Generate ***/
 // Build //
 func generateTestVolumeSetSpec(state string) *client.VolumeSetSpec {
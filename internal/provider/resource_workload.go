@@ -8,6 +8,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,6 +26,7 @@ func resourceWorkload() *schema.Resource {
 		ReadContext:   resourceWorkloadRead,
 		UpdateContext: resourceWorkloadUpdate,
 		DeleteContext: resourceWorkloadDelete,
+		CustomizeDiff: customizeDiffWorkload,
 		Schema: map[string]*schema.Schema{
 			"gvc": {
 				Type:         schema.TypeString,
@@ -79,25 +81,9 @@ func resourceWorkload() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-
-								warns, errs = NameValidator(val, key)
-
-								v := val.(string)
-
-								if strings.HasPrefix(v, "cpln-") {
-									errs = append(errs, fmt.Errorf("%q cannot start with 'cpln-', got: %s", key, v))
-									return
-								}
-
-								if v == "istio-proxy" || v == "queue-proxy" || v == "istio-validation" || v == "cpln-envoy-assassin" || v == "cpln-writer-proxy" || v == "cpln-reader-proxy" || v == "cpln-dbaas-config" {
-									errs = append(errs, fmt.Errorf("%q cannot be set to 'istio-proxy', 'queue-proxy', 'istio-validation', 'cpln-envoy-assassin', 'cpln-writer-proxy', 'cpln-reader-proxy', 'cpln-dbaas-config', got: %s", key, v))
-								}
-
-								return
-							},
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: ContainerNameValidator,
 						},
 						"image": {
 							Type:     schema.TypeString,
@@ -156,6 +142,16 @@ func resourceWorkload() *schema.Resource {
 							Default:      "128Mi",
 							ValidateFunc: CpuMemoryValidator,
 						},
+						"min_cpu": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: CpuMemoryValidator,
+						},
+						"min_memory": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: CpuMemoryValidator,
+						},
 						"working_directory": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -181,26 +177,71 @@ func resourceWorkload() *schema.Resource {
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
-							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-
-								v := val.(map[string]interface{})
-
-								for name, value := range v {
-
-									nameLower := strings.ToLower(name)
-
-									if nameLower == "k_service" || nameLower == "k_configuration" || nameLower == "k_revision" {
-										errs = append(errs, fmt.Errorf("%q cannot be 'K_SERVICE', 'K_CONFIGURATION', 'K_REVISION', got: %s", key, nameLower))
-									}
+							ValidateFunc: ContainerEnvValidator,
+						},
+						"env_from": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"secret": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"key": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+									"gvc_env": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+									"workload_reference": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"field": {
+													Type:     schema.TypeString,
+													Required: true,
+													ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
 
-									maxValueLength := 4 * 1024
+														v := val.(string)
 
-									if len(value.(string)) > maxValueLength {
-										errs = append(errs, fmt.Errorf("%q length cannot be > %d, got: %d", key, maxValueLength, len(value.(string))))
-									}
-								}
+														if _, ok := workloadReferenceFields[v]; !ok {
+															errs = append(errs, fmt.Errorf("%q must be 'workload', 'gvc', 'org', 'location' or 'provider', got: %s", key, v))
+														}
 
-								return
+														return
+													},
+												},
+											},
+										},
+									},
+								},
 							},
 						},
 						"inherit_env": {
@@ -231,50 +272,128 @@ func resourceWorkload() *schema.Resource {
 							Type:     schema.TypeList,
 							Optional: true,
 							MaxItems: 5,
+							Elem:     volumeSpec(),
+						},
+						"secret_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"secret_link": {
+										Type:             schema.TypeString,
+										Required:         true,
+										DiffSuppressFunc: DiffSuppressLinkName,
+									},
+									"path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: VolumePathValidator,
+									},
+									"items": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"key": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"path": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"volumeset_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"volumeset_link": {
+										Type:             schema.TypeString,
+										Required:         true,
+										DiffSuppressFunc: DiffSuppressLinkName,
+									},
+									"path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: VolumePathValidator,
+									},
+								},
+							},
+						},
+						"s3_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
-									"uri": {
+									"bucket": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: VolumePathValidator,
+									},
+								},
+							},
+						},
+						"gcs_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"bucket": {
 										Type:     schema.TypeString,
 										Required: true,
-										ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-
-											v := val.(string)
-
-											re := regexp.MustCompile(`^(s3|gs|azureblob|azurefs|cpln|scratch):\/\/.+`)
-
-											if !re.MatchString(v) {
-												errs = append(errs, fmt.Errorf("%q must be in the form s3://bucket, gs://bucket, azureblob://storageAccount/container, azurefs://storageAccount/share, cpln://, scratch://, got: %s", key, v))
-											}
-
-											return
-										},
 									},
-									"recovery_policy": {
+									"path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: VolumePathValidator,
+									},
+								},
+							},
+						},
+						"scratch_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
 										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "retain",
+										Required: true,
 									},
 									"path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: VolumePathValidator,
+									},
+								},
+							},
+						},
+						"azure_blob_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"storage_account": {
 										Type:     schema.TypeString,
 										Required: true,
-										ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-
-											v := val.(string)
-
-											if !path.IsAbs(v) {
-												errs = append(errs, fmt.Errorf("%q must be an absolute path, got: %s", key, v))
-												return
-											}
-
-											v = path.Clean(v)
-											v = strings.TrimRight(v, "/")
-
-											if v == "/dev/log" || v == "/dev" || v == "/tmp" || v == "/var" || v == "/var/log" {
-												errs = append(errs, fmt.Errorf("%q is set to a reserved path, got: %s", key, v))
-											}
-
-											return
-										},
+									},
+									"container": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"path": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: VolumePathValidator,
 									},
 								},
 							},
@@ -321,6 +440,49 @@ func resourceWorkload() *schema.Resource {
 					},
 				},
 			},
+			"init_container": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 20,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: ContainerNameValidator,
+						},
+						"image": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							ValidateFunc: ContainerEnvValidator,
+						},
+						"volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 5,
+							Elem:     volumeSpec(),
+						},
+					},
+				},
+			},
 			"options": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -360,6 +522,24 @@ func resourceWorkload() *schema.Resource {
 							MaxItems: 1,
 							Elem:     AutoScalingResource(),
 						},
+						"multi_zone": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     MultiZoneResource(),
+						},
+						"spot": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     SpotResource(),
+						},
+						"node_affinity": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     NodeAffinityResource(),
+						},
 					},
 				},
 			},
@@ -405,6 +585,24 @@ func resourceWorkload() *schema.Resource {
 							MaxItems: 1,
 							Elem:     AutoScalingResource(),
 						},
+						"multi_zone": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     MultiZoneResource(),
+						},
+						"spot": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     SpotResource(),
+						},
+						"node_affinity": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem:     NodeAffinityResource(),
+						},
 					},
 				},
 			},
@@ -462,120 +660,51 @@ func resourceWorkload() *schema.Resource {
 				},
 			},
 			"status": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     WorkloadStatusResource(),
+			},
+			"include_volume_status": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"volume_status": {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"parent_id": {
+						"volume_set": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Computed: true,
 						},
-						"canonical_endpoint": {
+						"location": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Computed: true,
+						},
+						"replica": {
+							Type:     schema.TypeInt,
+							Computed: true,
 						},
-						"endpoint": {
+						"lifecycle": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Computed: true,
 						},
-						"internal_name": {
+						"zone": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Computed: true,
 						},
-						"health_check": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"active": {
-										Type:     schema.TypeBool,
-										Required: true,
-									},
-									"success": {
-										Type:     schema.TypeBool,
-										Optional: true,
-									},
-									"code": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
-									"message": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"failures": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
-									"successes": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
-									"last_checked": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-								},
-							},
+						"storage_device_id": {
+							Type:     schema.TypeString,
+							Computed: true,
 						},
-						"current_replica_count": {
+						"current_size": {
 							Type:     schema.TypeInt,
-							Optional: true,
+							Computed: true,
 						},
-						"resolved_images": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"resolved_for_version": {
-										Type:     schema.TypeInt,
-										Optional: true,
-									},
-									"resolved_at": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"images": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"digest": {
-													Type:     schema.TypeString,
-													Optional: true,
-												},
-												"manifests": {
-													Type:     schema.TypeList,
-													Optional: true,
-													Elem: &schema.Resource{
-														Schema: map[string]*schema.Schema{
-															"image": {
-																Type:     schema.TypeString,
-																Optional: true,
-															},
-															"media_type": {
-																Type:     schema.TypeString,
-																Optional: true,
-															},
-															"digest": {
-																Type:     schema.TypeString,
-																Optional: true,
-															},
-															"platform": {
-																Type:     schema.TypeMap,
-																Optional: true,
-																Elem: &schema.Schema{
-																	Type: schema.TypeString,
-																},
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
+						"current_bytes_used": {
+							Type:     schema.TypeInt,
+							Computed: true,
 						},
 					},
 				},
@@ -640,6 +769,244 @@ func resourceWorkload() *schema.Resource {
 					},
 				},
 			},
+			"request_retry_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attempts": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  2,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 1 || v > 10 {
+									errs = append(errs, fmt.Errorf("%q must be between 1 and 10 inclusive, got: %d", key, v))
+								}
+								return
+							},
+						},
+						"retry_on": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+									v := val.(string)
+
+									if v != "5xx" && v != "gateway-error" && v != "reset" && v != "connect-failure" && v != "retriable-4xx" && v != "refused-stream" && v != "retriable-status-codes" {
+										errs = append(errs, fmt.Errorf("%q must be '5xx', 'gateway-error', 'reset', 'connect-failure', 'retriable-4xx', 'refused-stream' or 'retriable-status-codes', got: %s", key, v))
+									}
+
+									return
+								},
+							},
+						},
+					},
+				},
+			},
+			"route": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+								v := val.(string)
+
+								if !strings.HasPrefix(v, "/") {
+									errs = append(errs, fmt.Errorf("%q must start with '/', got: %s", key, v))
+								}
+
+								return
+							},
+						},
+						"timeout_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 1 || v > 3600 {
+									errs = append(errs, fmt.Errorf("%q must be between 1 and 3600 inclusive, got: %d", key, v))
+								}
+								return
+							},
+						},
+						"workload_link": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: LinkValidator,
+						},
+						"header": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"exact": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"prefix": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"regex": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"mirror": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"workload_link": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: LinkValidator,
+						},
+						"percent": {
+							Type:     schema.TypeFloat,
+							Optional: true,
+							Default:  100,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(float64)
+								if v <= 0 || v > 100 {
+									errs = append(errs, fmt.Errorf("%q must be greater than 0 and at most 100, got: %f", key, v))
+								}
+								return
+							},
+						},
+					},
+				},
+			},
+			"load_balancer": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"direct": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:     schema.TypeBool,
+										Required: true,
+									},
+									"ports": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"external_port": {
+													Type:     schema.TypeInt,
+													Required: true,
+													ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+														v := val.(int)
+														if v < 22 || v > 32768 {
+															errs = append(errs, fmt.Errorf("%q must be between 22 and 32768 inclusive, got: %d", key, v))
+														}
+														return
+													},
+												},
+												"protocol": {
+													Type:     schema.TypeString,
+													Required: true,
+													ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+														v := val.(string)
+														if v != "TCP" && v != "UDP" {
+															errs = append(errs, fmt.Errorf("%q must be either 'TCP' or 'UDP', got: %s", key, v))
+														}
+														return
+													},
+												},
+												"scheme": {
+													Type:     schema.TypeString,
+													Optional: true,
+													ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+														v := val.(string)
+														if v != "http" && v != "tcp" && v != "https" && v != "ws" && v != "wss" {
+															errs = append(errs, fmt.Errorf("%q must be either 'http', 'tcp', 'https', 'ws' or 'wss', got: %s", key, v))
+														}
+														return
+													},
+												},
+												"container_port": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+														v := val.(int)
+														if v < 80 || v > 65535 {
+															errs = append(errs, fmt.Errorf("%q must be between 80 and 65535 inclusive, got: %d", key, v))
+														}
+														return
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"geo_location": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"enabled": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"headers": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"asn": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"city": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"country": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"region": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: importStateWorkload,
@@ -661,6 +1028,61 @@ func importStateWorkload(ctx context.Context, d *schema.ResourceData, meta inter
 	return []*schema.ResourceData{d}, nil
 }
 
+func customizeDiffWorkload(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+
+	if !diff.NewValueKnown("container") {
+		return nil
+	}
+
+	containers := diff.Get("container").([]interface{})
+
+	if err := validateEnvFrom(containers); err != nil {
+		return err
+	}
+
+	c := m.(*client.Client)
+
+	if diff.NewValueKnown("options") && diff.NewValueKnown("local_options") {
+
+		workloadSpec := &client.WorkloadSpec{}
+
+		buildContainers(containers, workloadSpec)
+		buildOptions(diff.Get("options").([]interface{}), workloadSpec, false, c.Org)
+		buildOptions(diff.Get("local_options").([]interface{}), workloadSpec, true, c.Org)
+
+		if err := validateContainerResources(workloadSpec); err != nil {
+			return err
+		}
+	}
+
+	if !diff.HasChanges("container", "init_container", "identity_link") {
+		return nil
+	}
+
+	if diff.HasChanges("container", "init_container") && diff.NewValueKnown("init_container") && diff.NewValueKnown("gvc") && diff.NewValueKnown("name") {
+
+		workloadSpec := &client.WorkloadSpec{}
+		buildContainers(containers, workloadSpec)
+		workloadSpec.InitContainers = buildInitContainers(diff.Get("init_container").([]interface{}))
+
+		if err := validateWorkloadVolumeSets(c, diff.Get("gvc").(string), diff.Get("name").(string), workloadSpec); err != nil {
+			return err
+		}
+	}
+
+	if !diff.HasChanges("container", "identity_link") || !diff.NewValueKnown("identity_link") || !diff.NewValueKnown("gvc") {
+		return nil
+	}
+
+	identityLink := strings.TrimSpace(diff.Get("identity_link").(string))
+
+	if len(getEnvFromSecretNames(containers)) > 0 && identityLink == "" {
+		return fmt.Errorf("env_from - an identity_link is required to reference secrets")
+	}
+
+	return validateEnvFromSecrets(c, diff.Get("gvc").(string), containers, identityLink)
+}
+
 func resourceWorkloadCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// log.Printf("[INFO] Method: resourceWorkloadCreate")
@@ -695,10 +1117,6 @@ func resourceWorkloadCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	workload.Spec.Type = GetString(strings.TrimSpace(d.Get("type").(string)))
 
-	if e := workloadSpecValidate(workload.Spec); e != nil {
-		return e
-	}
-
 	if d.Get("identity_link") != nil {
 
 		identityLink := strings.TrimSpace(d.Get("identity_link").(string))
@@ -729,16 +1147,33 @@ func resourceWorkloadCreate(ctx context.Context, d *schema.ResourceData, m inter
 		workload.Spec.Sidecar = buildWorkloadSidecar(d.Get("sidecar").([]interface{}))
 	}
 
-	newWorkload, code, err := c.CreateWorkload(workload, gvcName)
-
-	if code == 409 {
-		return ResourceExistsHelper()
+	if d.Get("load_balancer") != nil {
+		workload.Spec.LoadBalancer = buildWorkloadLoadBalancer(d.Get("load_balancer").([]interface{}))
 	}
 
-	if err != nil {
+	workload.Spec.RequestRetryPolicy = buildRequestRetryPolicy(d.Get("request_retry_policy").([]interface{}))
+	workload.Spec.Routes = buildWorkloadRoutes(d.Get("route").([]interface{}))
+	workload.Spec.Mirror = buildWorkloadMirror(d.Get("mirror").([]interface{}))
+	workload.Spec.InitContainers = buildInitContainers(d.Get("init_container").([]interface{}))
+
+	if e := workloadSpecValidate(workload.Spec); e != nil {
+		return e
+	}
+
+	newWorkload, code, err := c.CreateWorkload(workload, gvcName)
+
+	if code == 409 {
+		return ResourceExistsHelper()
+	}
+
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if e := setWorkloadVolumeStatus(d, c, gvcName, newWorkload); e != nil {
+		return e
+	}
+
 	return setWorkload(d, newWorkload, gvcName, c.Org, legacyPort, nil)
 }
 
@@ -807,6 +1242,10 @@ func resourceWorkloadRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 	// log.Printf("Before Calling SET: Endpoint: %s. Canonical: %s", workload.Status.Endpoint, workload.Status.CanonicalEndpoint)
 
+	if e := setWorkloadVolumeStatus(d, c, gvcName, workload); e != nil {
+		return e
+	}
+
 	return setWorkload(d, workload, gvcName, c.Org, legacyPort, diags)
 }
 
@@ -814,7 +1253,7 @@ func resourceWorkloadUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 	// log.Printf("[INFO] Method: resourceWorkloadUpdate")
 
-	if d.HasChanges("description", "tags", "type", "container", "options", "local_options", "firewall_spec", "job", "identity_link", "rollout_options", "security_options", "support_dynamic_tags", "sidecar") {
+	if d.HasChanges("description", "tags", "type", "container", "options", "local_options", "firewall_spec", "job", "identity_link", "rollout_options", "security_options", "support_dynamic_tags", "sidecar", "load_balancer", "request_retry_policy", "route", "mirror", "init_container") {
 
 		if checkLegacyPort(d.Get("container").([]interface{})) {
 			var diags diag.Diagnostics
@@ -849,6 +1288,11 @@ func resourceWorkloadUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		workloadToUpdate.SpecReplace.SecurityOptions = buildSecurityOptions(d.Get("security_options").([]interface{}))
 		workloadToUpdate.SpecReplace.SupportDynamicTags = GetBool(d.Get("support_dynamic_tags"))
 		workloadToUpdate.SpecReplace.Sidecar = buildWorkloadSidecar(d.Get("sidecar").([]interface{}))
+		workloadToUpdate.SpecReplace.LoadBalancer = buildWorkloadLoadBalancer(d.Get("load_balancer").([]interface{}))
+		workloadToUpdate.SpecReplace.RequestRetryPolicy = buildRequestRetryPolicy(d.Get("request_retry_policy").([]interface{}))
+		workloadToUpdate.SpecReplace.Routes = buildWorkloadRoutes(d.Get("route").([]interface{}))
+		workloadToUpdate.SpecReplace.Mirror = buildWorkloadMirror(d.Get("mirror").([]interface{}))
+		workloadToUpdate.SpecReplace.InitContainers = buildInitContainers(d.Get("init_container").([]interface{}))
 
 		if d.Get("identity_link") != nil {
 
@@ -878,6 +1322,10 @@ func resourceWorkloadUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			updatedWorkload.Status.CanonicalEndpoint = &testEndpoint
 		}
 
+		if e := setWorkloadVolumeStatus(d, c, gvcName, updatedWorkload); e != nil {
+			return e
+		}
+
 		return setWorkload(d, updatedWorkload, gvcName, c.Org, legacyPort, nil)
 	}
 
@@ -913,7 +1361,11 @@ func setWorkload(d *schema.ResourceData, workload *client.Workload, gvcName, org
 	}
 
 	if workload.Spec != nil {
-		if err := d.Set("container", flattenContainer(workload.Spec.Containers, legacyPort)); err != nil {
+		if err := d.Set("container", flattenContainer(workload.Spec.Containers, legacyPort, getTypedVolumePaths(d.Get("container").([]interface{})), getEnvFromNames(d.Get("container").([]interface{})))); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("init_container", flattenInitContainers(workload.Spec.InitContainers)); err != nil {
 			return diag.FromErr(err)
 		}
 
@@ -960,6 +1412,22 @@ func setWorkload(d *schema.ResourceData, workload *client.Workload, gvcName, org
 		if err := d.Set("sidecar", flattenWorkloadSidecar(workload.Spec.Sidecar)); err != nil {
 			return diag.FromErr(err)
 		}
+
+		if err := d.Set("load_balancer", flattenWorkloadLoadBalancer(workload.Spec.LoadBalancer)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("request_retry_policy", flattenRequestRetryPolicy(workload.Spec.RequestRetryPolicy)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("route", flattenWorkloadRoutes(workload.Spec.Routes)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("mirror", flattenWorkloadMirror(workload.Spec.Mirror)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	if err := d.Set("status", flattenWorkloadStatus(workload.Status)); err != nil {
@@ -973,6 +1441,124 @@ func setWorkload(d *schema.ResourceData, workload *client.Workload, gvcName, org
 	return diags
 }
 
+func setWorkloadVolumeStatus(d *schema.ResourceData, c *client.Client, gvcName string, workload *client.Workload) diag.Diagnostics {
+
+	volumeSets := []client.VolumeSet{}
+
+	// Looking up the volume sets costs a request per volume set, only do so when the status was asked for
+	if d.Get("include_volume_status").(bool) && workload.Spec != nil && workload.Spec.Type != nil && *workload.Spec.Type == "stateful" {
+
+		for _, volumeSetName := range getWorkloadVolumeSetNames(workload.Spec) {
+
+			volumeSet, code, err := c.GetVolumeSet(volumeSetName, gvcName)
+
+			// The volume set may have been removed out of band, report the volumes that can still be found
+			if code == 404 {
+				continue
+			}
+
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			volumeSets = append(volumeSets, *volumeSet)
+		}
+	}
+
+	if err := d.Set("volume_status", flattenWorkloadVolumeStatus(volumeSets)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// workloadReferenceFields - Fields of the running workload that can be referenced by env_from, mapped to the variable injected by Control Plane
+var workloadReferenceFields = map[string]string{
+	"workload": "CPLN_WORKLOAD",
+	"gvc":      "CPLN_GVC",
+	"org":      "CPLN_ORG",
+	"location": "CPLN_LOCATION",
+	"provider": "CPLN_PROVIDER",
+}
+
+// getEnvFromNames - Collects, per container, the names of the env variables declared using env_from
+func getEnvFromNames(containers []interface{}) map[string]map[string]bool {
+
+	output := map[string]map[string]bool{}
+
+	for _, container := range containers {
+
+		c := container.(map[string]interface{})
+		names := map[string]bool{}
+
+		if c["env_from"] != nil {
+			for _, value := range c["env_from"].([]interface{}) {
+				if value != nil {
+					names[value.(map[string]interface{})["name"].(string)] = true
+				}
+			}
+		}
+
+		output[c["name"].(string)] = names
+	}
+
+	return output
+}
+
+// getTypedVolumeBlock - Returns the typed volume block that can represent the uri, or an empty string if there is none
+func getTypedVolumeBlock(uri *string) string {
+
+	if uri == nil {
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(*uri, "cpln://secret/"):
+		return "secret_volume"
+	case strings.HasPrefix(*uri, "cpln://volumeset/"):
+		return "volumeset_volume"
+	case strings.HasPrefix(*uri, "s3://"):
+		return "s3_volume"
+	case strings.HasPrefix(*uri, "gs://"):
+		return "gcs_volume"
+	case strings.HasPrefix(*uri, "azureblob://") && strings.Contains(strings.TrimPrefix(*uri, "azureblob://"), "/"):
+		return "azure_blob_volume"
+	case strings.HasPrefix(*uri, "scratch://"):
+		return "scratch_volume"
+	}
+
+	return ""
+}
+
+// getTypedVolumePaths - Collects, per container, the mount paths of the volumes declared using a typed volume block
+func getTypedVolumePaths(containers []interface{}) map[string]map[string]bool {
+
+	output := map[string]map[string]bool{}
+
+	for _, container := range containers {
+
+		c := container.(map[string]interface{})
+		paths := map[string]bool{}
+
+		for _, block := range []string{"secret_volume", "volumeset_volume", "s3_volume", "gcs_volume", "azure_blob_volume", "scratch_volume"} {
+
+			if c[block] == nil {
+				continue
+			}
+
+			for _, value := range c[block].([]interface{}) {
+				if value != nil {
+					paths[value.(map[string]interface{})["path"].(string)] = true
+				}
+			}
+		}
+
+		output[c["name"].(string)] = paths
+	}
+
+	return output
+}
+
 func checkLegacyPort(containers []interface{}) bool {
 
 	if containers == nil {
@@ -1016,6 +1602,14 @@ func buildContainers(containers []interface{}, workloadSpec *client.WorkloadSpec
 			WorkingDirectory: GetString(c["working_directory"].(string)),
 		}
 
+		if c["min_cpu"] != nil {
+			newContainer.MinCPU = GetString(c["min_cpu"].(string))
+		}
+
+		if c["min_memory"] != nil {
+			newContainer.MinMemory = GetString(c["min_memory"].(string))
+		}
+
 		if c["gpu_nvidia"] != nil {
 			newContainer.GPU = buildGpuNvidia(c["gpu_nvidia"].([]interface{}))
 		}
@@ -1066,6 +1660,10 @@ func buildContainers(containers []interface{}, workloadSpec *client.WorkloadSpec
 			envArray = append(envArray, newEnv)
 		}
 
+		if c["env_from"] != nil {
+			envArray = append(envArray, buildEnvFrom(c["env_from"].([]interface{}))...)
+		}
+
 		if len(envArray) > 0 {
 			newContainer.Env = &envArray
 		}
@@ -1078,8 +1676,18 @@ func buildContainers(containers []interface{}, workloadSpec *client.WorkloadSpec
 			newContainer.LivenessProbe = buildHealthCheckSpec(c["liveness_probe"].([]interface{}))
 		}
 
+		volumes := []client.VolumeSpec{}
+
 		if c["volume"] != nil {
-			newContainer.Volumes = buildVolumeSpec(c["volume"].([]interface{}))
+			if rawVolumes := buildVolumeSpec(c["volume"].([]interface{})); rawVolumes != nil {
+				volumes = append(volumes, *rawVolumes...)
+			}
+		}
+
+		volumes = append(volumes, buildTypedVolumes(c)...)
+
+		if len(volumes) > 0 {
+			newContainer.Volumes = &volumes
 		}
 
 		if c["metrics"] != nil {
@@ -1141,6 +1749,198 @@ func buildGpuNvidia(specs []interface{}) *client.GpuResource {
 	return &gpuResource
 }
 
+func buildTypedVolumes(container map[string]interface{}) []client.VolumeSpec {
+
+	output := []client.VolumeSpec{}
+
+	if container["secret_volume"] != nil {
+		for _, value := range container["secret_volume"].([]interface{}) {
+
+			v := value.(map[string]interface{})
+			volume := client.VolumeSpec{
+				Uri:  GetString(fmt.Sprintf("cpln://secret/%s", GetNameFromSelfLink(v["secret_link"].(string)))),
+				Path: GetString(v["path"]),
+			}
+
+			if v["items"] != nil && len(v["items"].([]interface{})) > 0 {
+
+				items := []client.VolumeItem{}
+
+				for _, i := range v["items"].([]interface{}) {
+
+					item := i.(map[string]interface{})
+
+					items = append(items, client.VolumeItem{
+						Key:  GetString(item["key"]),
+						Path: GetString(item["path"]),
+					})
+				}
+
+				volume.Items = &items
+			}
+
+			output = append(output, volume)
+		}
+	}
+
+	if container["volumeset_volume"] != nil {
+		for _, value := range container["volumeset_volume"].([]interface{}) {
+
+			v := value.(map[string]interface{})
+
+			output = append(output, client.VolumeSpec{
+				Uri:  GetString(fmt.Sprintf("cpln://volumeset/%s", GetNameFromSelfLink(v["volumeset_link"].(string)))),
+				Path: GetString(v["path"]),
+			})
+		}
+	}
+
+	if container["s3_volume"] != nil {
+		for _, value := range container["s3_volume"].([]interface{}) {
+
+			v := value.(map[string]interface{})
+
+			output = append(output, client.VolumeSpec{
+				Uri:  GetString(fmt.Sprintf("s3://%s", v["bucket"].(string))),
+				Path: GetString(v["path"]),
+			})
+		}
+	}
+
+	if container["gcs_volume"] != nil {
+		for _, value := range container["gcs_volume"].([]interface{}) {
+
+			v := value.(map[string]interface{})
+
+			output = append(output, client.VolumeSpec{
+				Uri:  GetString(fmt.Sprintf("gs://%s", v["bucket"].(string))),
+				Path: GetString(v["path"]),
+			})
+		}
+	}
+
+	if container["azure_blob_volume"] != nil {
+		for _, value := range container["azure_blob_volume"].([]interface{}) {
+
+			v := value.(map[string]interface{})
+
+			output = append(output, client.VolumeSpec{
+				Uri:  GetString(fmt.Sprintf("azureblob://%s/%s", v["storage_account"].(string), v["container"].(string))),
+				Path: GetString(v["path"]),
+			})
+		}
+	}
+
+	if container["scratch_volume"] != nil {
+		for _, value := range container["scratch_volume"].([]interface{}) {
+
+			v := value.(map[string]interface{})
+
+			output = append(output, client.VolumeSpec{
+				Uri:  GetString(fmt.Sprintf("scratch://%s", v["name"].(string))),
+				Path: GetString(v["path"]),
+			})
+		}
+	}
+
+	return output
+}
+
+func buildEnvFrom(specs []interface{}) []client.NameValue {
+
+	output := []client.NameValue{}
+
+	for _, value := range specs {
+
+		if value == nil {
+			continue
+		}
+
+		spec := value.(map[string]interface{})
+		envValue := ""
+
+		if secret := spec["secret"].([]interface{}); len(secret) > 0 && secret[0] != nil {
+
+			s := secret[0].(map[string]interface{})
+			envValue = fmt.Sprintf("cpln://secret/%s", s["name"].(string))
+
+			if key := s["key"].(string); key != "" {
+				envValue = fmt.Sprintf("%s.%s", envValue, key)
+			}
+		} else if gvcEnv := spec["gvc_env"].([]interface{}); len(gvcEnv) > 0 && gvcEnv[0] != nil {
+			envValue = fmt.Sprintf("$(%s)", gvcEnv[0].(map[string]interface{})["name"].(string))
+		} else if reference := spec["workload_reference"].([]interface{}); len(reference) > 0 && reference[0] != nil {
+			envValue = fmt.Sprintf("$(%s)", workloadReferenceFields[reference[0].(map[string]interface{})["field"].(string)])
+		}
+
+		output = append(output, client.NameValue{
+			Name:  GetString(spec["name"]),
+			Value: &envValue,
+		})
+	}
+
+	return output
+}
+
+func buildInitContainers(initContainers []interface{}) *[]client.ContainerSpec {
+
+	if len(initContainers) == 0 {
+		return nil
+	}
+
+	output := []client.ContainerSpec{}
+
+	for _, initContainer := range initContainers {
+
+		c := initContainer.(map[string]interface{})
+
+		newInitContainer := client.ContainerSpec{
+			Name:    GetString(c["name"].(string)),
+			Image:   GetString(c["image"].(string)),
+			Command: GetString(c["command"].(string)),
+		}
+
+		args := []string{}
+
+		for _, value := range c["args"].([]interface{}) {
+			if value != nil {
+				args = append(args, value.(string))
+			}
+		}
+
+		if len(args) > 0 {
+			newInitContainer.Args = &args
+		}
+
+		envs := []client.NameValue{}
+
+		keys, m := MapSortHelper(c["env"])
+
+		for _, k := range keys {
+
+			name := k
+			value := m[k].(string)
+
+			envs = append(envs, client.NameValue{
+				Name:  &name,
+				Value: &value,
+			})
+		}
+
+		if len(envs) > 0 {
+			newInitContainer.Env = &envs
+		}
+
+		if c["volume"] != nil {
+			newInitContainer.Volumes = buildVolumeSpec(c["volume"].([]interface{}))
+		}
+
+		output = append(output, newInitContainer)
+	}
+
+	return &output
+}
+
 func buildVolumeSpec(volumes []interface{}) *[]client.VolumeSpec {
 
 	if len(volumes) > 0 {
@@ -1398,9 +2198,29 @@ func buildOptions(options []interface{}, workloadSpec *client.WorkloadSpec, loca
 					ScaleToZeroDelay: GetInt(as["scale_to_zero_delay"]),
 				}
 
+				if as["multi"] != nil {
+					cas.Multi = buildMultiMetrics(as["multi"].([]interface{}))
+				}
+
+				if as["keda"] != nil {
+					cas.Keda = buildKedaAutoScaling(as["keda"].([]interface{}))
+				}
+
 				newOptions.AutoScaling = &cas
 			}
 
+			if option["multi_zone"] != nil {
+				newOptions.MultiZone = buildMultiZone(option["multi_zone"].([]interface{}))
+			}
+
+			if option["spot"] != nil {
+				newOptions.Spot = buildSpotOptions(option["spot"].([]interface{}))
+			}
+
+			if option["node_affinity"] != nil {
+				newOptions.NodeAffinity = buildNodeAffinity(option["node_affinity"].([]interface{}))
+			}
+
 			output = append(output, newOptions)
 		}
 	}
@@ -1416,31 +2236,184 @@ func buildOptions(options []interface{}, workloadSpec *client.WorkloadSpec, loca
 	}
 }
 
-func buildFirewallSpec(specs []interface{}, workloadSpec *client.WorkloadSpec) {
+func buildMultiMetrics(specs []interface{}) *[]client.MultiMetric {
 
-	if len(specs) > 0 {
+	if len(specs) == 0 {
+		return nil
+	}
 
-		newSpec := client.FirewallSpec{}
-		workloadSpec.FirewallConfig = &newSpec
+	output := []client.MultiMetric{}
 
-		if specs[0] == nil {
-			return
-		}
+	for _, m := range specs {
 
-		spec := specs[0].(map[string]interface{})
-		external := spec["external"].([]interface{})
+		metric := m.(map[string]interface{})
 
-		if len(external) > 0 {
+		output = append(output, client.MultiMetric{
+			Metric: GetString(metric["metric"]),
+			Target: GetInt(metric["target"]),
+		})
+	}
 
-			we := client.FirewallSpecExternal{}
-			newSpec.External = &we
+	return &output
+}
 
-			if external[0] != nil {
+func buildKedaAutoScaling(specs []interface{}) *client.KedaAutoScaling {
 
-				e := external[0].(map[string]interface{})
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
 
-				if e["inbound_allow_cidr"] != nil {
-					inboundAllowCIDR := []string{}
+	spec := specs[0].(map[string]interface{})
+	output := client.KedaAutoScaling{
+		PollingInterval: GetInt(spec["polling_interval"]),
+		CooldownPeriod:  GetInt(spec["cooldown_period"]),
+	}
+
+	if spec["trigger"] != nil {
+
+		triggers := []client.KedaTrigger{}
+
+		for _, t := range spec["trigger"].([]interface{}) {
+
+			trigger := t.(map[string]interface{})
+			newTrigger := client.KedaTrigger{
+				Type:             GetString(trigger["type"]),
+				Name:             GetString(trigger["name"]),
+				MetricType:       GetString(trigger["metric_type"]),
+				UseCachedMetrics: GetBool(trigger["use_cached_metrics"]),
+			}
+
+			if trigger["metadata"] != nil && len(trigger["metadata"].(map[string]interface{})) > 0 {
+				newTrigger.Metadata = GetStringMap(trigger["metadata"])
+			}
+
+			triggers = append(triggers, newTrigger)
+		}
+
+		output.Triggers = &triggers
+	}
+
+	return &output
+}
+
+func buildMultiZone(specs []interface{}) *client.MultiZone {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+
+	return &client.MultiZone{
+		Enabled: GetBool(spec["enabled"]),
+	}
+}
+
+func buildSpotOptions(specs []interface{}) *client.SpotOptions {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+
+	return &client.SpotOptions{
+		Enabled:    GetBool(spec["enabled"]),
+		Preference: GetString(spec["preference"]),
+	}
+}
+
+func buildNodeAffinity(specs []interface{}) *client.NodeAffinity {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.NodeAffinity{}
+
+	if spec["required"] != nil {
+		output.Required = buildNodeSelectorRequirements(spec["required"].([]interface{}))
+	}
+
+	if spec["preferred"] != nil && len(spec["preferred"].([]interface{})) > 0 {
+
+		preferred := []client.PreferredNodeAffinity{}
+
+		for _, p := range spec["preferred"].([]interface{}) {
+
+			preference := p.(map[string]interface{})
+
+			preferred = append(preferred, client.PreferredNodeAffinity{
+				Weight:           GetInt(preference["weight"]),
+				MatchExpressions: buildNodeSelectorRequirements(preference["match_expression"].([]interface{})),
+			})
+		}
+
+		output.Preferred = &preferred
+	}
+
+	return &output
+}
+
+func buildNodeSelectorRequirements(specs []interface{}) *[]client.NodeSelectorRequirement {
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	output := []client.NodeSelectorRequirement{}
+
+	for _, e := range specs {
+
+		expression := e.(map[string]interface{})
+		requirement := client.NodeSelectorRequirement{
+			Key:      GetString(expression["key"]),
+			Operator: GetString(expression["operator"]),
+		}
+
+		if expression["values"] != nil && len(expression["values"].([]interface{})) > 0 {
+
+			values := []string{}
+
+			for _, v := range expression["values"].([]interface{}) {
+				values = append(values, v.(string))
+			}
+
+			requirement.Values = &values
+		}
+
+		output = append(output, requirement)
+	}
+
+	return &output
+}
+
+func buildFirewallSpec(specs []interface{}, workloadSpec *client.WorkloadSpec) {
+
+	if len(specs) > 0 {
+
+		newSpec := client.FirewallSpec{}
+		workloadSpec.FirewallConfig = &newSpec
+
+		if specs[0] == nil {
+			return
+		}
+
+		spec := specs[0].(map[string]interface{})
+		external := spec["external"].([]interface{})
+
+		if len(external) > 0 {
+
+			we := client.FirewallSpecExternal{}
+			newSpec.External = &we
+
+			if external[0] != nil {
+
+				e := external[0].(map[string]interface{})
+
+				if e["inbound_allow_cidr"] != nil {
+					inboundAllowCIDR := []string{}
 
 					for _, value := range e["inbound_allow_cidr"].(*schema.Set).List() {
 						inboundAllowCIDR = append(inboundAllowCIDR, value.(string))
@@ -1661,6 +2634,184 @@ func buildWorkloadSidecar(specs []interface{}) *client.WorkloadSidecar {
 	return &output
 }
 
+func buildRequestRetryPolicy(specs []interface{}) *client.RequestRetryPolicy {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.RequestRetryPolicy{
+		Attempts: GetInt(spec["attempts"]),
+	}
+
+	if spec["retry_on"] != nil {
+		output.RetryOn = buildStringArray(spec["retry_on"].(*schema.Set).List())
+
+		// Keep the order stable, sets are not ordered
+		if output.RetryOn != nil {
+			sort.Strings(*output.RetryOn)
+		}
+	}
+
+	return &output
+}
+
+func buildWorkloadRoutes(specs []interface{}) *[]client.WorkloadRoute {
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	output := []client.WorkloadRoute{}
+
+	for _, value := range specs {
+
+		spec := value.(map[string]interface{})
+		route := client.WorkloadRoute{
+			Prefix:       GetString(spec["prefix"]),
+			WorkloadLink: GetString(spec["workload_link"]),
+		}
+
+		if timeout := spec["timeout_seconds"].(int); timeout > 0 {
+			route.TimeoutSeconds = GetInt(timeout)
+		}
+
+		if spec["header"] != nil && len(spec["header"].([]interface{})) > 0 {
+
+			headers := []client.WorkloadRouteHeaderMatch{}
+
+			for _, h := range spec["header"].([]interface{}) {
+
+				header := h.(map[string]interface{})
+
+				headers = append(headers, client.WorkloadRouteHeaderMatch{
+					Name:   GetString(header["name"]),
+					Exact:  GetString(header["exact"]),
+					Prefix: GetString(header["prefix"]),
+					Regex:  GetString(header["regex"]),
+				})
+			}
+
+			route.Headers = &headers
+		}
+
+		output = append(output, route)
+	}
+
+	return &output
+}
+
+func buildWorkloadMirror(specs []interface{}) *[]client.WorkloadMirror {
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	output := []client.WorkloadMirror{}
+
+	for _, value := range specs {
+
+		spec := value.(map[string]interface{})
+
+		output = append(output, client.WorkloadMirror{
+			WorkloadLink: GetString(spec["workload_link"]),
+			Percent:      GetFloat64(spec["percent"]),
+		})
+	}
+
+	return &output
+}
+
+func buildWorkloadLoadBalancer(specs []interface{}) *client.WorkloadLoadBalancer {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.WorkloadLoadBalancer{}
+
+	if spec["direct"] != nil {
+		output.Direct = buildDirectLoadBalancer(spec["direct"].([]interface{}))
+	}
+
+	if spec["geo_location"] != nil {
+		output.GeoLocation = buildGeoLocation(spec["geo_location"].([]interface{}))
+	}
+
+	return &output
+}
+
+func buildDirectLoadBalancer(specs []interface{}) *client.DirectLoadBalancer {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.DirectLoadBalancer{
+		Enabled: GetBool(spec["enabled"]),
+	}
+
+	if spec["ports"] != nil && len(spec["ports"].([]interface{})) > 0 {
+
+		ports := []client.DirectLoadBalancerPort{}
+
+		for _, p := range spec["ports"].([]interface{}) {
+
+			port := p.(map[string]interface{})
+			newPort := client.DirectLoadBalancerPort{
+				ExternalPort: GetInt(port["external_port"]),
+				Protocol:     GetString(port["protocol"]),
+				Scheme:       GetString(port["scheme"]),
+			}
+
+			// Zero means the container port was not set
+			if port["container_port"] != nil && port["container_port"].(int) != 0 {
+				newPort.ContainerPort = GetInt(port["container_port"])
+			}
+
+			ports = append(ports, newPort)
+		}
+
+		output.Ports = &ports
+	}
+
+	return &output
+}
+
+func buildGeoLocation(specs []interface{}) *client.GeoLocation {
+
+	if len(specs) == 0 || specs[0] == nil {
+		return nil
+	}
+
+	spec := specs[0].(map[string]interface{})
+	output := client.GeoLocation{
+		Enabled: GetBool(spec["enabled"]),
+	}
+
+	if spec["headers"] != nil {
+
+		headers := spec["headers"].([]interface{})
+
+		if len(headers) > 0 && headers[0] != nil {
+
+			header := headers[0].(map[string]interface{})
+
+			output.Headers = &client.GeoLocationHeaders{
+				Asn:     GetString(header["asn"]),
+				City:    GetString(header["city"]),
+				Country: GetString(header["country"]),
+				Region:  GetString(header["region"]),
+			}
+		}
+	}
+
+	return &output
+}
+
 /*** Flatten ***/
 
 func flattenWorkloadStatus(status *client.WorkloadStatus) []interface{} {
@@ -1728,6 +2879,11 @@ func flattenWorkloadStatus(status *client.WorkloadStatus) []interface{} {
 			fs["resolved_images"] = resolvedImages
 		}
 
+		loadBalancer := flattenWorkloadStatusLoadBalancer(status.LoadBalancer)
+		if loadBalancer != nil {
+			fs["load_balancer"] = loadBalancer
+		}
+
 		output := []interface{}{
 			fs,
 		}
@@ -1738,6 +2894,36 @@ func flattenWorkloadStatus(status *client.WorkloadStatus) []interface{} {
 	return nil
 }
 
+func flattenWorkloadStatusLoadBalancer(loadBalancers *[]client.LoadBalancerStatus) []interface{} {
+
+	if loadBalancers == nil || len(*loadBalancers) == 0 {
+		return nil
+	}
+
+	output := make([]interface{}, len(*loadBalancers))
+
+	for i, loadBalancer := range *loadBalancers {
+
+		lb := make(map[string]interface{})
+
+		if loadBalancer.Origin != nil {
+			lb["origin"] = *loadBalancer.Origin
+		}
+
+		if loadBalancer.Url != nil {
+			lb["url"] = *loadBalancer.Url
+		}
+
+		if loadBalancer.IpAddresses != nil {
+			lb["ip_addresses"] = flattenStringsArray(loadBalancer.IpAddresses)
+		}
+
+		output[i] = lb
+	}
+
+	return output
+}
+
 func flattenWorkloadStatusResolvedImages(resolvedImages *client.ResolvedImages) []interface{} {
 	if resolvedImages == nil {
 		return nil
@@ -1826,7 +3012,7 @@ func flattenWorkloadStatusManifest(manifests *[]client.ResolvedImageManifest) []
 	return specs
 }
 
-func flattenContainer(containers *[]client.ContainerSpec, legacyPort bool) []interface{} {
+func flattenContainer(containers *[]client.ContainerSpec, legacyPort bool, typedVolumePaths map[string]map[string]bool, envFromNames map[string]map[string]bool) []interface{} {
 
 	if containers != nil && len(*containers) > 0 {
 
@@ -1857,6 +3043,14 @@ func flattenContainer(containers *[]client.ContainerSpec, legacyPort bool) []int
 			c["cpu"] = *container.CPU
 			c["memory"] = *container.Memory
 
+			if container.MinCPU != nil {
+				c["min_cpu"] = *container.MinCPU
+			}
+
+			if container.MinMemory != nil {
+				c["min_memory"] = *container.MinMemory
+			}
+
 			if container.GPU != nil && container.GPU.Nvidia != nil {
 				c["gpu_nvidia"] = flattenGpuNvidia(container.GPU)
 			}
@@ -1883,12 +3077,28 @@ func flattenContainer(containers *[]client.ContainerSpec, legacyPort bool) []int
 
 			if container.Env != nil && len(*container.Env) > 0 {
 				envs := make(map[string]interface{})
+				envFrom := []interface{}{}
 
 				for _, env := range *container.Env {
+
+					// Values are only flattened into env_from when they were declared there
+					if envFromNames[*container.Name][*env.Name] {
+						if flattenedEnv := flattenEnvFrom(env); flattenedEnv != nil {
+							envFrom = append(envFrom, flattenedEnv)
+							continue
+						}
+					}
+
 					envs[*env.Name] = *env.Value
 				}
 
-				c["env"] = envs
+				if len(envs) > 0 {
+					c["env"] = envs
+				}
+
+				if len(envFrom) > 0 {
+					c["env_from"] = envFrom
+				}
 			}
 
 			if container.LivenessProbe != nil {
@@ -1900,7 +3110,25 @@ func flattenContainer(containers *[]client.ContainerSpec, legacyPort bool) []int
 			}
 
 			if container.Volumes != nil {
-				c["volume"] = flattenVolumeSpec(container.Volumes)
+
+				rawVolumes := []client.VolumeSpec{}
+				typedVolumes := []client.VolumeSpec{}
+
+				for _, volume := range *container.Volumes {
+
+					// Volumes are only flattened into a typed block when they were declared as one, raw uris are kept as is
+					if volume.Path != nil && typedVolumePaths[*container.Name][*volume.Path] && getTypedVolumeBlock(volume.Uri) != "" {
+						typedVolumes = append(typedVolumes, volume)
+					} else {
+						rawVolumes = append(rawVolumes, volume)
+					}
+				}
+
+				c["volume"] = flattenVolumeSpec(&rawVolumes)
+
+				for block, volumes := range flattenTypedVolumes(typedVolumes) {
+					c[block] = volumes
+				}
 			}
 
 			if container.Metrics != nil {
@@ -1920,13 +3148,178 @@ func flattenContainer(containers *[]client.ContainerSpec, legacyPort bool) []int
 	return nil
 }
 
-func flattenVolumeSpec(volumes *[]client.VolumeSpec) []interface{} {
+func flattenInitContainers(initContainers *[]client.ContainerSpec) []interface{} {
 
-	if volumes != nil && len(*volumes) > 0 {
+	if initContainers == nil || len(*initContainers) == 0 {
+		return nil
+	}
 
-		output := []interface{}{}
+	output := []interface{}{}
 
-		for _, volume := range *volumes {
+	for _, initContainer := range *initContainers {
+
+		c := map[string]interface{}{
+			"name":  *initContainer.Name,
+			"image": *initContainer.Image,
+		}
+
+		if initContainer.Command != nil {
+			c["command"] = *initContainer.Command
+		}
+
+		if initContainer.Args != nil && len(*initContainer.Args) > 0 {
+			c["args"] = *initContainer.Args
+		}
+
+		if initContainer.Env != nil && len(*initContainer.Env) > 0 {
+
+			envs := make(map[string]interface{})
+
+			for _, env := range *initContainer.Env {
+				if env.Name != nil && env.Value != nil {
+					envs[*env.Name] = *env.Value
+				}
+			}
+
+			c["env"] = envs
+		}
+
+		if initContainer.Volumes != nil {
+			c["volume"] = flattenVolumeSpec(initContainer.Volumes)
+		}
+
+		output = append(output, c)
+	}
+
+	return output
+}
+
+func flattenEnvFrom(env client.NameValue) map[string]interface{} {
+
+	if env.Name == nil || env.Value == nil {
+		return nil
+	}
+
+	value := *env.Value
+	output := map[string]interface{}{
+		"name": *env.Name,
+	}
+
+	if strings.HasPrefix(value, "cpln://secret/") {
+
+		parts := strings.SplitN(strings.TrimPrefix(value, "cpln://secret/"), ".", 2)
+		secret := map[string]interface{}{
+			"name": parts[0],
+		}
+
+		if len(parts) == 2 {
+			secret["key"] = parts[1]
+		}
+
+		output["secret"] = []interface{}{secret}
+
+		return output
+	}
+
+	if !strings.HasPrefix(value, "$(") || !strings.HasSuffix(value, ")") {
+		return nil
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(value, "$("), ")")
+
+	for field, variable := range workloadReferenceFields {
+		if name == variable {
+			output["workload_reference"] = []interface{}{
+				map[string]interface{}{
+					"field": field,
+				},
+			}
+
+			return output
+		}
+	}
+
+	output["gvc_env"] = []interface{}{
+		map[string]interface{}{
+			"name": name,
+		},
+	}
+
+	return output
+}
+
+func flattenTypedVolumes(volumes []client.VolumeSpec) map[string]interface{} {
+
+	output := map[string]interface{}{}
+
+	for _, volume := range volumes {
+
+		block := getTypedVolumeBlock(volume.Uri)
+
+		if block == "" {
+			continue
+		}
+
+		v := map[string]interface{}{
+			"path": *volume.Path,
+		}
+
+		switch block {
+		case "secret_volume":
+			v["secret_link"] = strings.TrimPrefix(*volume.Uri, "cpln://secret/")
+
+			if volume.Items != nil {
+
+				items := []interface{}{}
+
+				for _, item := range *volume.Items {
+
+					i := map[string]interface{}{}
+
+					if item.Key != nil {
+						i["key"] = *item.Key
+					}
+
+					if item.Path != nil {
+						i["path"] = *item.Path
+					}
+
+					items = append(items, i)
+				}
+
+				v["items"] = items
+			}
+		case "volumeset_volume":
+			v["volumeset_link"] = strings.TrimPrefix(*volume.Uri, "cpln://volumeset/")
+		case "s3_volume":
+			v["bucket"] = strings.TrimPrefix(*volume.Uri, "s3://")
+		case "gcs_volume":
+			v["bucket"] = strings.TrimPrefix(*volume.Uri, "gs://")
+		case "azure_blob_volume":
+			parts := strings.SplitN(strings.TrimPrefix(*volume.Uri, "azureblob://"), "/", 2)
+			v["storage_account"] = parts[0]
+			v["container"] = parts[1]
+		case "scratch_volume":
+			v["name"] = strings.TrimPrefix(*volume.Uri, "scratch://")
+		}
+
+		if output[block] == nil {
+			output[block] = []interface{}{}
+		}
+
+		output[block] = append(output[block].([]interface{}), v)
+	}
+
+	return output
+}
+
+func flattenVolumeSpec(volumes *[]client.VolumeSpec) []interface{} {
+
+	if volumes != nil && len(*volumes) > 0 {
+
+		output := []interface{}{}
+
+		for _, volume := range *volumes {
 
 			v := map[string]interface{}{}
 
@@ -2166,11 +3559,32 @@ func flattenOptions(options []client.Options, localOptions bool, org string) []i
 				if o.AutoScaling.ScaleToZeroDelay != nil {
 					as["scale_to_zero_delay"] = *o.AutoScaling.ScaleToZeroDelay
 				}
+
+				if o.AutoScaling.Multi != nil {
+					as["multi"] = flattenMultiMetrics(o.AutoScaling.Multi)
+				}
+
+				if o.AutoScaling.Keda != nil {
+					as["keda"] = flattenKedaAutoScaling(o.AutoScaling.Keda)
+				}
+
 				autoScaling := make([]interface{}, 1)
 				autoScaling[0] = as
 				option["autoscaling"] = autoScaling
 			}
 
+			if o.MultiZone != nil {
+				option["multi_zone"] = flattenMultiZone(o.MultiZone)
+			}
+
+			if o.Spot != nil {
+				option["spot"] = flattenSpotOptions(o.Spot)
+			}
+
+			if o.NodeAffinity != nil {
+				option["node_affinity"] = flattenNodeAffinity(o.NodeAffinity)
+			}
+
 			output = append(output, option)
 		}
 
@@ -2180,6 +3594,259 @@ func flattenOptions(options []client.Options, localOptions bool, org string) []i
 	return nil
 }
 
+func flattenMultiMetrics(metrics *[]client.MultiMetric) []interface{} {
+
+	if metrics == nil {
+		return nil
+	}
+
+	output := []interface{}{}
+
+	for _, m := range *metrics {
+
+		metric := make(map[string]interface{})
+
+		if m.Metric != nil {
+			metric["metric"] = *m.Metric
+		}
+
+		if m.Target != nil {
+			metric["target"] = *m.Target
+		}
+
+		output = append(output, metric)
+	}
+
+	return output
+}
+
+func flattenKedaAutoScaling(keda *client.KedaAutoScaling) []interface{} {
+
+	if keda == nil {
+		return nil
+	}
+
+	spec := make(map[string]interface{})
+
+	if keda.PollingInterval != nil {
+		spec["polling_interval"] = *keda.PollingInterval
+	}
+
+	if keda.CooldownPeriod != nil {
+		spec["cooldown_period"] = *keda.CooldownPeriod
+	}
+
+	if keda.Triggers != nil {
+
+		triggers := []interface{}{}
+
+		for _, t := range *keda.Triggers {
+
+			trigger := make(map[string]interface{})
+
+			if t.Type != nil {
+				trigger["type"] = *t.Type
+			}
+
+			if t.Name != nil {
+				trigger["name"] = *t.Name
+			}
+
+			if t.Metadata != nil {
+				trigger["metadata"] = *t.Metadata
+			}
+
+			if t.MetricType != nil {
+				trigger["metric_type"] = *t.MetricType
+			}
+
+			if t.UseCachedMetrics != nil {
+				trigger["use_cached_metrics"] = *t.UseCachedMetrics
+			}
+
+			triggers = append(triggers, trigger)
+		}
+
+		spec["trigger"] = triggers
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenMultiZone(multiZone *client.MultiZone) []interface{} {
+
+	if multiZone == nil {
+		return nil
+	}
+
+	spec := make(map[string]interface{})
+
+	if multiZone.Enabled != nil {
+		spec["enabled"] = *multiZone.Enabled
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenSpotOptions(spot *client.SpotOptions) []interface{} {
+
+	if spot == nil {
+		return nil
+	}
+
+	spec := make(map[string]interface{})
+
+	if spot.Enabled != nil {
+		spec["enabled"] = *spot.Enabled
+	}
+
+	if spot.Preference != nil {
+		spec["preference"] = *spot.Preference
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenNodeAffinity(nodeAffinity *client.NodeAffinity) []interface{} {
+
+	if nodeAffinity == nil {
+		return nil
+	}
+
+	spec := make(map[string]interface{})
+
+	if nodeAffinity.Required != nil {
+		spec["required"] = flattenNodeSelectorRequirements(nodeAffinity.Required)
+	}
+
+	if nodeAffinity.Preferred != nil {
+
+		preferred := []interface{}{}
+
+		for _, p := range *nodeAffinity.Preferred {
+
+			preference := make(map[string]interface{})
+
+			if p.Weight != nil {
+				preference["weight"] = *p.Weight
+			}
+
+			if p.MatchExpressions != nil {
+				preference["match_expression"] = flattenNodeSelectorRequirements(p.MatchExpressions)
+			}
+
+			preferred = append(preferred, preference)
+		}
+
+		spec["preferred"] = preferred
+	}
+
+	return []interface{}{
+		spec,
+	}
+}
+
+func flattenNodeSelectorRequirements(requirements *[]client.NodeSelectorRequirement) []interface{} {
+
+	if requirements == nil {
+		return nil
+	}
+
+	output := []interface{}{}
+
+	for _, r := range *requirements {
+
+		expression := make(map[string]interface{})
+
+		if r.Key != nil {
+			expression["key"] = *r.Key
+		}
+
+		if r.Operator != nil {
+			expression["operator"] = *r.Operator
+		}
+
+		if r.Values != nil {
+
+			values := []interface{}{}
+
+			for _, v := range *r.Values {
+				values = append(values, v)
+			}
+
+			expression["values"] = values
+		}
+
+		output = append(output, expression)
+	}
+
+	return output
+}
+
+func flattenWorkloadVolumeStatus(volumeSets []client.VolumeSet) []interface{} {
+
+	output := []interface{}{}
+
+	for _, volumeSet := range volumeSets {
+
+		if volumeSet.Status == nil || volumeSet.Status.Locations == nil {
+			continue
+		}
+
+		for _, location := range *volumeSet.Status.Locations {
+
+			if location.Volumes == nil {
+				continue
+			}
+
+			for _, volume := range *location.Volumes {
+
+				status := map[string]interface{}{
+					"volume_set": *volumeSet.Name,
+				}
+
+				if location.Name != nil {
+					status["location"] = GetNameFromSelfLink(*location.Name)
+				}
+
+				if volume.Index != nil {
+					status["replica"] = *volume.Index
+				}
+
+				if volume.Lifecycle != nil {
+					status["lifecycle"] = *volume.Lifecycle
+				}
+
+				if volume.Zone != nil {
+					status["zone"] = *volume.Zone
+				}
+
+				if volume.StorageDeviceId != nil {
+					status["storage_device_id"] = *volume.StorageDeviceId
+				}
+
+				if volume.CurrentSize != nil {
+					status["current_size"] = *volume.CurrentSize
+				}
+
+				if volume.CurrentBytesUsed != nil {
+					status["current_bytes_used"] = int(*volume.CurrentBytesUsed)
+				}
+
+				output = append(output, status)
+			}
+		}
+	}
+
+	return output
+}
+
 func flattenFirewallSpec(spec *client.FirewallSpec) []interface{} {
 
 	if spec != nil {
@@ -2428,19 +4095,236 @@ func flattenWorkloadSidecar(spec *client.WorkloadSidecar) []interface{} {
 	}
 }
 
-/*** Helpers ***/
-func AutoScalingResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"metric": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+func flattenRequestRetryPolicy(spec *client.RequestRetryPolicy) []interface{} {
+
+	if spec == nil {
+		return nil
+	}
+
+	policy := map[string]interface{}{}
+
+	if spec.Attempts != nil {
+		policy["attempts"] = *spec.Attempts
+	}
+
+	if spec.RetryOn != nil {
+		policy["retry_on"] = flattenStringsArray(spec.RetryOn)
+	}
+
+	return []interface{}{
+		policy,
+	}
+}
+
+func flattenWorkloadRoutes(routes *[]client.WorkloadRoute) []interface{} {
+
+	if routes == nil {
+		return nil
+	}
+
+	output := []interface{}{}
+
+	for _, r := range *routes {
+
+		route := map[string]interface{}{}
+
+		if r.Prefix != nil {
+			route["prefix"] = *r.Prefix
+		}
+
+		if r.TimeoutSeconds != nil {
+			route["timeout_seconds"] = *r.TimeoutSeconds
+		}
+
+		if r.WorkloadLink != nil {
+			route["workload_link"] = *r.WorkloadLink
+		}
+
+		if r.Headers != nil {
+
+			headers := []interface{}{}
+
+			for _, h := range *r.Headers {
+
+				header := map[string]interface{}{}
+
+				if h.Name != nil {
+					header["name"] = *h.Name
+				}
+
+				if h.Exact != nil {
+					header["exact"] = *h.Exact
+				}
+
+				if h.Prefix != nil {
+					header["prefix"] = *h.Prefix
+				}
+
+				if h.Regex != nil {
+					header["regex"] = *h.Regex
+				}
+
+				headers = append(headers, header)
+			}
+
+			route["header"] = headers
+		}
+
+		output = append(output, route)
+	}
+
+	return output
+}
+
+func flattenWorkloadMirror(mirrors *[]client.WorkloadMirror) []interface{} {
+
+	if mirrors == nil {
+		return nil
+	}
+
+	output := []interface{}{}
+
+	for _, m := range *mirrors {
+
+		mirror := map[string]interface{}{}
+
+		if m.WorkloadLink != nil {
+			mirror["workload_link"] = *m.WorkloadLink
+		}
+
+		if m.Percent != nil {
+			mirror["percent"] = *m.Percent
+		}
+
+		output = append(output, mirror)
+	}
+
+	return output
+}
+
+func flattenWorkloadLoadBalancer(spec *client.WorkloadLoadBalancer) []interface{} {
+
+	if spec == nil {
+		return nil
+	}
+
+	loadBalancer := map[string]interface{}{}
+
+	if spec.Direct != nil {
+		loadBalancer["direct"] = flattenDirectLoadBalancer(spec.Direct)
+	}
+
+	if spec.GeoLocation != nil {
+		loadBalancer["geo_location"] = flattenGeoLocation(spec.GeoLocation)
+	}
+
+	return []interface{}{
+		loadBalancer,
+	}
+}
+
+func flattenDirectLoadBalancer(spec *client.DirectLoadBalancer) []interface{} {
+
+	if spec == nil {
+		return nil
+	}
+
+	direct := map[string]interface{}{}
+
+	if spec.Enabled != nil {
+		direct["enabled"] = *spec.Enabled
+	}
+
+	if spec.Ports != nil && len(*spec.Ports) > 0 {
+
+		ports := []interface{}{}
+
+		for _, p := range *spec.Ports {
+
+			port := map[string]interface{}{}
+
+			if p.ExternalPort != nil {
+				port["external_port"] = *p.ExternalPort
+			}
+
+			if p.Protocol != nil {
+				port["protocol"] = *p.Protocol
+			}
+
+			if p.Scheme != nil {
+				port["scheme"] = *p.Scheme
+			}
+
+			if p.ContainerPort != nil {
+				port["container_port"] = *p.ContainerPort
+			}
+
+			ports = append(ports, port)
+		}
+
+		direct["ports"] = ports
+	}
+
+	return []interface{}{
+		direct,
+	}
+}
+
+func flattenGeoLocation(spec *client.GeoLocation) []interface{} {
+
+	if spec == nil {
+		return nil
+	}
+
+	geoLocation := map[string]interface{}{}
+
+	if spec.Enabled != nil {
+		geoLocation["enabled"] = *spec.Enabled
+	}
+
+	if spec.Headers != nil {
+
+		headers := map[string]interface{}{}
+
+		if spec.Headers.Asn != nil {
+			headers["asn"] = *spec.Headers.Asn
+		}
+
+		if spec.Headers.City != nil {
+			headers["city"] = *spec.Headers.City
+		}
+
+		if spec.Headers.Country != nil {
+			headers["country"] = *spec.Headers.Country
+		}
+
+		if spec.Headers.Region != nil {
+			headers["region"] = *spec.Headers.Region
+		}
+
+		geoLocation["headers"] = []interface{}{
+			headers,
+		}
+	}
+
+	return []interface{}{
+		geoLocation,
+	}
+}
+
+/*** Helpers ***/
+func AutoScalingResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metric": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
 
 					v := val.(string)
 
-					if v != "concurrency" && v != "cpu" && v != "rps" && v != "latency" && v != "disabled" {
-						errs = append(errs, fmt.Errorf("%q must be 'concurrency', 'cpu', 'rps', 'latency' or 'disabled', got: %s", key, v))
+					if v != "concurrency" && v != "cpu" && v != "rps" && v != "latency" && v != "multi" && v != "keda" && v != "disabled" {
+						errs = append(errs, fmt.Errorf("%q must be 'concurrency', 'cpu', 'rps', 'latency', 'multi', 'keda' or 'disabled', got: %s", key, v))
 					}
 
 					return
@@ -2520,46 +4404,111 @@ func AutoScalingResource() *schema.Resource {
 					return
 				},
 			},
-		},
-	}
-}
-
-func ExternalFirewallResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"inbound_allow_cidr": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-			"outbound_allow_cidr": {
-				Type:     schema.TypeSet,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-			"outbound_allow_hostname": {
-				Type:     schema.TypeSet,
+			"multi": {
+				Type:     schema.TypeList,
 				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metric": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+								v := val.(string)
+
+								if v != "cpu" && v != "memory" {
+									errs = append(errs, fmt.Errorf("%q must be either 'cpu' or 'memory', got: %s", key, v))
+								}
+
+								return
+							},
+						},
+						"target": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 1 || v > 20000 {
+									errs = append(errs, fmt.Errorf("%q must be between 1 and 20000 inclusive, got: %d", key, v))
+								}
+								return
+							},
+						},
+					},
 				},
 			},
-			"outbound_allow_port": {
+			"keda": {
 				Type:     schema.TypeList,
 				Optional: true,
+				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"protocol": {
-							Type:     schema.TypeString,
+						"trigger": {
+							Type:     schema.TypeList,
 							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"metadata": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"metric_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+											v := val.(string)
+
+											if v != "AverageValue" && v != "Value" && v != "Utilization" {
+												errs = append(errs, fmt.Errorf("%q must be 'AverageValue', 'Value' or 'Utilization', got: %s", key, v))
+											}
+
+											return
+										},
+									},
+									"use_cached_metrics": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+								},
+							},
 						},
-						"number": {
+						"polling_interval": {
 							Type:     schema.TypeInt,
-							Required: true,
+							Optional: true,
+							Default:  30,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 1 {
+									errs = append(errs, fmt.Errorf("%q must be >= 1, got: %d", key, v))
+								}
+								return
+							},
+						},
+						"cooldown_period": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  300,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 0 {
+									errs = append(errs, fmt.Errorf("%q must be >= 0, got: %d", key, v))
+								}
+								return
+							},
 						},
 					},
 				},
@@ -2568,25 +4517,318 @@ func ExternalFirewallResource() *schema.Resource {
 	}
 }
 
-func InternalFirewallResource() *schema.Resource {
+func MultiZoneResource() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
-			"inbound_allow_type": {
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func SpotResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"preference": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "none",
+				Default:  "preferred",
 				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
 
 					v := val.(string)
 
-					if v != "none" && v != "same-gvc" && v != "same-org" && v != "workload-list" {
-						errs = append(errs, fmt.Errorf("%q must be 'none', 'same-gvc', 'same-org', or 'workload-list', got: %s", key, v))
+					if v != "preferred" && v != "required" {
+						errs = append(errs, fmt.Errorf("%q must be either 'preferred' or 'required', got: %s", key, v))
 					}
 
 					return
 				},
 			},
-			"inbound_allow_workload": {
+		},
+	}
+}
+
+func NodeAffinityResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"required": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     NodeSelectorRequirementResource(),
+			},
+			"preferred": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"weight": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								v := val.(int)
+								if v < 1 || v > 100 {
+									errs = append(errs, fmt.Errorf("%q must be between 1 and 100 inclusive, got: %d", key, v))
+								}
+								return
+							},
+						},
+						"match_expression": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     NodeSelectorRequirementResource(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func NodeSelectorRequirementResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"operator": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(string)
+
+					if v != "In" && v != "NotIn" && v != "Exists" && v != "DoesNotExist" {
+						errs = append(errs, fmt.Errorf("%q must be either 'In', 'NotIn', 'Exists' or 'DoesNotExist', got: %s", key, v))
+					}
+
+					return
+				},
+			},
+			"values": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func WorkloadStatusResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"parent_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"canonical_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"internal_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"health_check": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"active": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"success": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"code": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"failures": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"successes": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"last_checked": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"current_replica_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"load_balancer": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"origin": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ip_addresses": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"resolved_images": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resolved_for_version": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"resolved_at": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"images": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"digest": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"manifests": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"image": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"media_type": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"digest": {
+													Type:     schema.TypeString,
+													Optional: true,
+												},
+												"platform": {
+													Type:     schema.TypeMap,
+													Optional: true,
+													Elem: &schema.Schema{
+														Type: schema.TypeString,
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ExternalFirewallResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"inbound_allow_cidr": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"outbound_allow_cidr": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"outbound_allow_hostname": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"outbound_allow_port": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"number": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func InternalFirewallResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"inbound_allow_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "none",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(string)
+
+					if v != "none" && v != "same-gvc" && v != "same-org" && v != "workload-list" {
+						errs = append(errs, fmt.Errorf("%q must be 'none', 'same-gvc', 'same-org', or 'workload-list', got: %s", key, v))
+					}
+
+					return
+				},
+			},
+			"inbound_allow_workload": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Schema{
@@ -2597,6 +4839,39 @@ func InternalFirewallResource() *schema.Resource {
 	}
 }
 
+func volumeSpec() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"uri": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+
+					v := val.(string)
+
+					re := regexp.MustCompile(`^(s3|gs|azureblob|azurefs|cpln|scratch):\/\/.+`)
+
+					if !re.MatchString(v) {
+						errs = append(errs, fmt.Errorf("%q must be in the form s3://bucket, gs://bucket, azureblob://storageAccount/container, azurefs://storageAccount/share, cpln://, scratch://, got: %s", key, v))
+					}
+
+					return
+				},
+			},
+			"recovery_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "retain",
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: VolumePathValidator,
+			},
+		},
+	}
+}
+
 func healthCheckSpec() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -2783,8 +5058,17 @@ func workloadSpecValidate(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
 			return diag.FromErr(fmt.Errorf("'job' section is required when workload type is 'cron'"))
 		}
 
-		if *workloadSpec.Type != "standard" && workloadSpec.RolloutOptions != nil {
-			return diag.FromErr(fmt.Errorf("rollout options are only available when workload type is 'standard'"))
+		if *workloadSpec.Type != "standard" && *workloadSpec.Type != "stateful" && workloadSpec.RolloutOptions != nil {
+			return diag.FromErr(fmt.Errorf("rollout options are only available when workload type is 'standard' or 'stateful'"))
+		}
+
+		// Each replica of a stateful workload owns its volume, an extra replica cannot be surged in during a rollout
+		if *workloadSpec.Type == "stateful" && workloadSpec.RolloutOptions != nil && workloadSpec.RolloutOptions.MaxSurgeReplicas != nil {
+			return diag.FromErr(fmt.Errorf("rollout options - max surge replicas is not allowed when workload type is 'stateful'"))
+		}
+
+		if e := validateWorkloadVolumes(workloadSpec); e != nil {
+			return e
 		}
 
 		for _, c := range *workloadSpec.Containers {
@@ -2794,23 +5078,22 @@ func workloadSpecValidate(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
 					return diag.FromErr(fmt.Errorf("probes are not allowed when workload type is 'cron'"))
 				}
 			}
+		}
 
-			if c.GPU != nil && c.GPU.Nvidia != nil {
-				cpuAmount, cpuUnit := ExtractNumberAndCharactersFromString(*c.CPU)
-				memoryAmount, memoryUnit := ExtractNumberAndCharactersFromString(*c.Memory)
+		if err := validateContainerResources(workloadSpec); err != nil {
+			return diag.FromErr(err)
+		}
 
-				// Return an error if the CPU amount is less than 2 and memory is less than 7Gi RAM
-				if (cpuUnit == "" && cpuAmount < 2) ||
-					(cpuUnit == "m" && cpuAmount < 2000) ||
-					(memoryUnit == "Gi" && memoryAmount < 7) ||
-					(memoryUnit == "Mi" && memoryAmount < 7000) {
-					return diag.FromErr(fmt.Errorf("the GPU requires this container to have at least 2 CPU Cores and 7 Gi RAM"))
-				}
+		if err := validateInitContainers(workloadSpec); err != nil {
+			return diag.FromErr(err)
+		}
 
-				if *workloadSpec.DefaultOptions.CapacityAI {
-					return diag.FromErr(fmt.Errorf("capacity AI must be disabled when using GPUs. Please remove the GPU selection from the containers or disable Capacity AI"))
-				}
-			}
+		if e := validateLoadBalancer(workloadSpec); e != nil {
+			return e
+		}
+
+		if e := validateWorkloadTraffic(workloadSpec); e != nil {
+			return e
 		}
 
 		if workloadSpec.DefaultOptions != nil {
@@ -2820,7 +5103,19 @@ func workloadSpecValidate(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
 		}
 
 		if workloadSpec.LocalOptions != nil && len(*workloadSpec.LocalOptions) > 0 {
+
+			locations := map[string]bool{}
+
 			for _, o := range *workloadSpec.LocalOptions {
+
+				if o.Location != nil {
+					if locations[*o.Location] {
+						return diag.FromErr(fmt.Errorf("local_options - location '%s' is defined more than once", GetNameFromSelfLink(*o.Location)))
+					}
+
+					locations[*o.Location] = true
+				}
+
 				if e := validateOptions(*workloadSpec.Type, "local_options - ", &o); e != nil {
 					return e
 				}
@@ -2831,27 +5126,778 @@ func workloadSpecValidate(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
 	return nil
 }
 
+func validateWorkloadVolumes(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
+
+	if workloadSpec.Containers == nil {
+		return nil
+	}
+
+	volumeSets := map[string]bool{}
+
+	for _, c := range *workloadSpec.Containers {
+
+		if c.Volumes == nil {
+			continue
+		}
+
+		paths := map[string]bool{}
+
+		for _, v := range *c.Volumes {
+
+			if v.Path != nil {
+				if paths[*v.Path] {
+					return diag.FromErr(fmt.Errorf("container '%s' - volume path '%s' is used more than once", *c.Name, *v.Path))
+				}
+
+				paths[*v.Path] = true
+			}
+
+			if v.Uri == nil || !strings.HasPrefix(*v.Uri, "cpln://volumeset/") {
+				continue
+			}
+
+			if *workloadSpec.Type != "stateful" {
+				return diag.FromErr(fmt.Errorf("volume set uri '%s' is only allowed when workload type is 'stateful'", *v.Uri))
+			}
+
+			volumeSetName := strings.TrimPrefix(*v.Uri, "cpln://volumeset/")
+
+			if volumeSets[volumeSetName] {
+				return diag.FromErr(fmt.Errorf("volume set '%s' can only be mounted once per workload", volumeSetName))
+			}
+
+			volumeSets[volumeSetName] = true
+		}
+	}
+
+	return nil
+}
+
+func validateWorkloadVolumeSets(c *client.Client, gvcName string, workloadName string, workloadSpec *client.WorkloadSpec) error {
+
+	for _, volumeSetName := range getWorkloadVolumeSetNames(workloadSpec) {
+
+		volumeSet, code, err := c.GetVolumeSet(volumeSetName, gvcName)
+
+		// The volume set may be created during the same apply, the API will reject the workload if it is still missing
+		if code == 404 {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if volumeSet.Status == nil || volumeSet.Status.UsedByWorkload == nil || strings.TrimSpace(*volumeSet.Status.UsedByWorkload) == "" {
+			continue
+		}
+
+		if usedBy := GetNameFromSelfLink(*volumeSet.Status.UsedByWorkload); usedBy != workloadName {
+			return fmt.Errorf("volume set '%s' is already used by workload '%s'. A volume set can only be mounted by one workload", volumeSetName, usedBy)
+		}
+	}
+
+	return nil
+}
+
+func getWorkloadVolumeSetNames(workloadSpec *client.WorkloadSpec) []string {
+
+	output := []string{}
+
+	if workloadSpec == nil {
+		return output
+	}
+
+	containers := []client.ContainerSpec{}
+	found := map[string]bool{}
+
+	if workloadSpec.Containers != nil {
+		containers = append(containers, *workloadSpec.Containers...)
+	}
+
+	if workloadSpec.InitContainers != nil {
+		containers = append(containers, *workloadSpec.InitContainers...)
+	}
+
+	for _, c := range containers {
+
+		if c.Volumes == nil {
+			continue
+		}
+
+		for _, v := range *c.Volumes {
+
+			if v.Uri == nil || !strings.HasPrefix(*v.Uri, "cpln://volumeset/") {
+				continue
+			}
+
+			// An init container may mount the same volume set as a container
+			if name := strings.TrimPrefix(*v.Uri, "cpln://volumeset/"); !found[name] {
+				found[name] = true
+				output = append(output, name)
+			}
+		}
+	}
+
+	return output
+}
+
+func validateEnvFrom(containers []interface{}) error {
+
+	for _, container := range containers {
+
+		c := container.(map[string]interface{})
+
+		if c["env_from"] == nil {
+			continue
+		}
+
+		names := map[string]bool{}
+
+		if c["env"] != nil {
+			for name := range c["env"].(map[string]interface{}) {
+				names[name] = true
+			}
+		}
+
+		for _, value := range c["env_from"].([]interface{}) {
+
+			if value == nil {
+				continue
+			}
+
+			spec := value.(map[string]interface{})
+			name := spec["name"].(string)
+
+			if names[name] {
+				return fmt.Errorf("container '%s' - env variable '%s' is defined more than once", c["name"], name)
+			}
+
+			names[name] = true
+
+			sources := 0
+
+			for _, source := range []string{"secret", "gvc_env", "workload_reference"} {
+				if spec[source] != nil && len(spec[source].([]interface{})) > 0 {
+					sources++
+				}
+			}
+
+			if sources != 1 {
+				return fmt.Errorf("container '%s' - env_from '%s' must define exactly one of 'secret', 'gvc_env' or 'workload_reference'", c["name"], name)
+			}
+
+			if spec["gvc_env"] != nil && len(spec["gvc_env"].([]interface{})) > 0 && !c["inherit_env"].(bool) {
+				return fmt.Errorf("container '%s' - env_from '%s' references a GVC env variable, 'inherit_env' must be enabled", c["name"], name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func getEnvFromSecretNames(containers []interface{}) []string {
+
+	output := []string{}
+	found := map[string]bool{}
+
+	for _, container := range containers {
+
+		c := container.(map[string]interface{})
+
+		if c["env_from"] == nil {
+			continue
+		}
+
+		for _, value := range c["env_from"].([]interface{}) {
+
+			if value == nil {
+				continue
+			}
+
+			secret := value.(map[string]interface{})["secret"].([]interface{})
+
+			if len(secret) == 0 || secret[0] == nil {
+				continue
+			}
+
+			// Unknown values are read as empty strings during plan
+			name := secret[0].(map[string]interface{})["name"].(string)
+
+			if name != "" && !found[name] {
+				found[name] = true
+				output = append(output, name)
+			}
+		}
+	}
+
+	return output
+}
+
+// validateEnvFromSecrets - The secrets referenced by env_from must exist and the workload identity must be granted 'reveal' on them.
+// Only the secret names known during plan are checked
+func validateEnvFromSecrets(c *client.Client, gvcName string, containers []interface{}, identityLink string) error {
+
+	secretNames := getEnvFromSecretNames(containers)
+
+	if len(secretNames) == 0 || identityLink == "" {
+		return nil
+	}
+
+	secrets := []*client.Secret{}
+
+	for _, secretName := range secretNames {
+
+		secret, code, err := c.GetSecretMetadata(secretName)
+
+		if code == 404 {
+			return fmt.Errorf("env_from - secret '%s' does not exist", secretName)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	policies, _, err := c.GetPolicies()
+
+	if err != nil {
+		return err
+	}
+
+	identityLink = getFullIdentityLink(c.Org, gvcName, identityLink)
+
+	for index, secret := range secrets {
+		if !hasSecretRevealPolicy(*policies, c.Org, gvcName, secretNames[index], secret, identityLink) {
+			return fmt.Errorf("env_from - identity '%s' must be granted the 'reveal' permission on secret '%s' by a policy", GetNameFromSelfLink(identityLink), secretNames[index])
+		}
+	}
+
+	return nil
+}
+
+// getFullIdentityLink - Expands a relative identity link ('//identity/name' or '//gvc/gvc/identity/name') into its full form
+func getFullIdentityLink(org string, gvcName string, identityLink string) string {
+
+	switch {
+	case strings.HasPrefix(identityLink, "//identity/"):
+		return fmt.Sprintf("/org/%s/gvc/%s%s", org, gvcName, strings.TrimPrefix(identityLink, "/"))
+	case strings.HasPrefix(identityLink, "//"):
+		return fmt.Sprintf("/org/%s%s", org, strings.TrimPrefix(identityLink, "/"))
+	}
+
+	return identityLink
+}
+
+// hasSecretRevealPolicy - Checks whether one of the policies grants the identity, given as a full link, the 'reveal' permission on the secret.
+// Relative principal links are expanded against the GVC of the workload
+func hasSecretRevealPolicy(policies []client.Policy, org string, gvcName string, secretName string, secret *client.Secret, identityLink string) bool {
+
+	secretLink := fmt.Sprintf("/org/%s/secret/%s", org, secretName)
+
+	for _, policy := range policies {
+
+		if policy.TargetKind == nil || *policy.TargetKind != "secret" || policy.Bindings == nil {
+			continue
+		}
+
+		targeted := (policy.Target != nil && *policy.Target == "all") || secretMatchesQuery(policy.TargetQuery, secretName, secret)
+
+		if !targeted && policy.TargetLinks != nil {
+			for _, link := range *policy.TargetLinks {
+				if link == secretLink || link == secretName {
+					targeted = true
+					break
+				}
+			}
+		}
+
+		if !targeted {
+			continue
+		}
+
+		for _, binding := range *policy.Bindings {
+
+			if binding.Permissions == nil || binding.PrincipalLinks == nil {
+				continue
+			}
+
+			permitted := false
+
+			for _, permission := range *binding.Permissions {
+				if permission == "reveal" || permission == "manage" {
+					permitted = true
+					break
+				}
+			}
+
+			if !permitted {
+				continue
+			}
+
+			for _, principalLink := range *binding.PrincipalLinks {
+				if getFullIdentityLink(org, gvcName, principalLink) == identityLink {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// secretMatchesQuery - Evaluates a policy target query against a secret. Only terms on tags or the name property using the
+// '=', '!=', 'exists' and '!exists' operators can be evaluated, a query using anything else is not counted as targeting the secret
+func secretMatchesQuery(query *client.Query, secretName string, secret *client.Secret) bool {
+
+	if query == nil || query.Spec == nil || query.Spec.Terms == nil || len(*query.Spec.Terms) == 0 {
+		return false
+	}
+
+	tags := map[string]interface{}{}
+
+	if secret != nil && secret.Tags != nil {
+		tags = *secret.Tags
+	}
+
+	matches := 0
+
+	for _, term := range *query.Spec.Terms {
+
+		var value interface{}
+		var found bool
+
+		switch {
+		case term.Rel != nil:
+			return false
+		case term.Tag != nil:
+			value, found = tags[*term.Tag]
+		case term.Property != nil && *term.Property == "name":
+			value, found = secretName, true
+		default:
+			return false
+		}
+
+		op := "="
+
+		if term.Op != nil {
+			op = *term.Op
+		}
+
+		var matched bool
+
+		switch op {
+		case "=", "!=":
+
+			expected := ""
+
+			if term.Value != nil {
+				expected = *term.Value
+			}
+
+			matched = found && fmt.Sprintf("%v", value) == expected
+
+			if op == "!=" {
+				matched = !matched
+			}
+
+		case "exists":
+			matched = found
+		case "!exists":
+			matched = !found
+		default:
+			return false
+		}
+
+		if matched {
+			matches++
+		}
+	}
+
+	match := "all"
+
+	if query.Spec.Match != nil {
+		match = *query.Spec.Match
+	}
+
+	switch match {
+	case "all":
+		return matches == len(*query.Spec.Terms)
+	case "any":
+		return matches > 0
+	case "none":
+		return matches == 0
+	}
+
+	return false
+}
+
+// validateInitContainers - Init container names must be unique across the workload and their volumes must be valid for the workload type
+func validateInitContainers(workloadSpec *client.WorkloadSpec) error {
+
+	if workloadSpec == nil || workloadSpec.InitContainers == nil {
+		return nil
+	}
+
+	names := map[string]bool{}
+
+	if workloadSpec.Containers != nil {
+		for _, c := range *workloadSpec.Containers {
+			if c.Name != nil {
+				names[*c.Name] = true
+			}
+		}
+	}
+
+	for _, c := range *workloadSpec.InitContainers {
+
+		if names[*c.Name] {
+			return fmt.Errorf("init container '%s' - the name is already used by another container or init container", *c.Name)
+		}
+
+		names[*c.Name] = true
+
+		if c.Volumes == nil {
+			continue
+		}
+
+		paths := map[string]bool{}
+
+		for _, volume := range *c.Volumes {
+
+			if volume.Uri != nil && strings.HasPrefix(*volume.Uri, "cpln://volumeset/") && *workloadSpec.Type != "stateful" {
+				return fmt.Errorf("init container '%s' - volume sets can only be mounted when workload type is 'stateful'", *c.Name)
+			}
+
+			if volume.Path != nil {
+
+				if paths[*volume.Path] {
+					return fmt.Errorf("init container '%s' - volume path '%s' is used more than once", *c.Name, *volume.Path)
+				}
+
+				paths[*volume.Path] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateContainerResources - Ensure container minimums do not exceed their limits and that CapacityAI constraints hold
+func validateContainerResources(workloadSpec *client.WorkloadSpec) error {
+
+	if workloadSpec == nil || workloadSpec.Containers == nil {
+		return nil
+	}
+
+	capacityAI := workloadSpec.DefaultOptions != nil && workloadSpec.DefaultOptions.CapacityAI != nil && *workloadSpec.DefaultOptions.CapacityAI
+
+	if workloadSpec.LocalOptions != nil {
+		for _, o := range *workloadSpec.LocalOptions {
+			if o.CapacityAI != nil && *o.CapacityAI {
+				capacityAI = true
+			}
+		}
+	}
+
+	for _, c := range *workloadSpec.Containers {
+
+		name := ""
+
+		if c.Name != nil {
+			name = *c.Name
+		}
+
+		cpu, cpuErr := parseContainerQuantity(c.CPU)
+		memory, memoryErr := parseContainerQuantity(c.Memory)
+
+		// The minimums are the resources reserved for the container when Capacity AI is disabled, cpu and memory being its limits.
+		// With Capacity AI enabled the reservations are managed by Capacity AI, so the minimums would be ignored
+		if c.MinCPU != nil || c.MinMemory != nil {
+
+			if capacityAI {
+				return fmt.Errorf("container '%s' - min_cpu and min_memory are only used when capacity AI is disabled. Please remove them or disable Capacity AI", name)
+			}
+
+			if minCpu, err := parseContainerQuantity(c.MinCPU); err == nil && cpuErr == nil && minCpu > cpu {
+				return fmt.Errorf("container '%s' - min_cpu (%s) cannot be greater than cpu (%s)", name, *c.MinCPU, *c.CPU)
+			}
+
+			if minMemory, err := parseContainerQuantity(c.MinMemory); err == nil && memoryErr == nil && minMemory > memory {
+				return fmt.Errorf("container '%s' - min_memory (%s) cannot be greater than memory (%s)", name, *c.MinMemory, *c.Memory)
+			}
+		}
+
+		if c.GPU != nil && c.GPU.Nvidia != nil {
+
+			// Return an error if the CPU amount is less than 2 cores or memory is less than 7Gi RAM
+			if (cpuErr == nil && cpu < 2) || (memoryErr == nil && memory < 7*(1<<30)) {
+				return fmt.Errorf("the GPU requires this container to have at least 2 CPU Cores and 7 Gi RAM")
+			}
+
+			if capacityAI {
+				return fmt.Errorf("capacity AI must be disabled when using GPUs. Please remove the GPU selection from the containers or disable Capacity AI")
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateNodeAffinity(nodeAffinity *client.NodeAffinity) error {
+
+	if nodeAffinity == nil {
+		return nil
+	}
+
+	requirements := []client.NodeSelectorRequirement{}
+
+	if nodeAffinity.Required != nil {
+		requirements = append(requirements, *nodeAffinity.Required...)
+	}
+
+	if nodeAffinity.Preferred != nil {
+		for _, p := range *nodeAffinity.Preferred {
+			if p.MatchExpressions != nil {
+				requirements = append(requirements, *p.MatchExpressions...)
+			}
+		}
+	}
+
+	for _, r := range requirements {
+
+		hasValues := r.Values != nil && len(*r.Values) > 0
+
+		switch *r.Operator {
+		case "In", "NotIn":
+			if !hasValues {
+				return fmt.Errorf("node affinity expression on key '%s' must list values when the operator is '%s'", *r.Key, *r.Operator)
+			}
+		case "Exists", "DoesNotExist":
+			if hasValues {
+				return fmt.Errorf("node affinity expression on key '%s' must not list values when the operator is '%s'", *r.Key, *r.Operator)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseContainerQuantity(value *string) (float64, error) {
+
+	if value == nil {
+		return 0, fmt.Errorf("quantity is not set")
+	}
+
+	return ParseQuantity(*value)
+}
+
 func validateOptions(workloadType, errorMsg string, options *client.Options) diag.Diagnostics {
 
-	if options != nil && options.AutoScaling != nil {
-		if workloadType == "cron" {
-			if options.CapacityAI != nil && *options.CapacityAI {
-				return diag.FromErr(fmt.Errorf(errorMsg + "capacity AI must be false when workload type is 'cron'"))
+	if options == nil {
+		return nil
+	}
+
+	if workloadType == "cron" && options.MultiZone != nil && options.MultiZone.Enabled != nil && *options.MultiZone.Enabled {
+		return diag.FromErr(fmt.Errorf(errorMsg + "multi zone is not allowed when workload type is 'cron'"))
+	}
+
+	// Spot nodes may be reclaimed at any time, stateful replicas are bound to their volumes and cannot be moved freely
+	if workloadType == "stateful" && options.Spot != nil && options.Spot.Enabled != nil && *options.Spot.Enabled {
+		return diag.FromErr(fmt.Errorf(errorMsg + "spot nodes are not allowed when workload type is 'stateful'"))
+	}
+
+	if err := validateNodeAffinity(options.NodeAffinity); err != nil {
+		return diag.FromErr(fmt.Errorf(errorMsg + err.Error()))
+	}
+
+	if options.AutoScaling == nil {
+		return nil
+	}
+
+	autoScaling := options.AutoScaling
+
+	if workloadType == "cron" {
+		if options.CapacityAI != nil && *options.CapacityAI {
+			return diag.FromErr(fmt.Errorf(errorMsg + "capacity AI must be false when workload type is 'cron'"))
+		}
+
+		if autoScaling.MinScale != nil && *autoScaling.MinScale != 1 {
+			return diag.FromErr(fmt.Errorf(errorMsg + "min scale must be set to 1 when workload type is 'cron'"))
+		}
+
+		if autoScaling.MaxScale != nil && *autoScaling.MaxScale != 1 {
+			return diag.FromErr(fmt.Errorf(errorMsg + "max scale must be set to 1 when workload type is 'cron'"))
+		}
+
+		if autoScaling.Multi != nil || autoScaling.Keda != nil {
+			return diag.FromErr(fmt.Errorf(errorMsg + "multi metrics and keda triggers are not allowed when workload type is 'cron'"))
+		}
+
+		return nil
+	}
+
+	if autoScaling.Metric == nil || strings.TrimSpace(*autoScaling.Metric) == "" {
+		return diag.FromErr(fmt.Errorf(errorMsg + "scaling strategy metric is required"))
+	}
+
+	metric := *autoScaling.Metric
+
+	if (metric == "multi" || metric == "keda") && workloadType != "standard" && workloadType != "stateful" {
+		return diag.FromErr(fmt.Errorf(errorMsg+"scaling strategy metric '%s' is only allowed when workload type is 'standard' or 'stateful'", metric))
+	}
+
+	if metric == "multi" {
+		if autoScaling.Multi == nil || len(*autoScaling.Multi) == 0 {
+			return diag.FromErr(fmt.Errorf(errorMsg + "at least one 'multi' metric is required when the scaling strategy metric is 'multi'"))
+		}
+
+		metrics := map[string]bool{}
+
+		for _, m := range *autoScaling.Multi {
+			if metrics[*m.Metric] {
+				return diag.FromErr(fmt.Errorf(errorMsg+"multi metric '%s' is defined more than once", *m.Metric))
+			}
+
+			metrics[*m.Metric] = true
+		}
+	} else if autoScaling.Multi != nil {
+		return diag.FromErr(fmt.Errorf(errorMsg + "'multi' metrics can only be set when the scaling strategy metric is 'multi'"))
+	}
+
+	if metric == "keda" {
+		if autoScaling.Keda == nil || autoScaling.Keda.Triggers == nil || len(*autoScaling.Keda.Triggers) == 0 {
+			return diag.FromErr(fmt.Errorf(errorMsg + "at least one 'keda' trigger is required when the scaling strategy metric is 'keda'"))
+		}
+	} else if autoScaling.Keda != nil {
+		return diag.FromErr(fmt.Errorf(errorMsg + "'keda' can only be set when the scaling strategy metric is 'keda'"))
+	}
+
+	return nil
+}
+
+func validateWorkloadTraffic(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
+
+	if *workloadSpec.Type == "cron" && (workloadSpec.RequestRetryPolicy != nil || workloadSpec.Routes != nil || workloadSpec.Mirror != nil) {
+		return diag.FromErr(fmt.Errorf("request_retry_policy, route and mirror are not allowed when workload type is 'cron'"))
+	}
+
+	if workloadSpec.Routes != nil {
+
+		prefixes := map[string]bool{}
+
+		for _, r := range *workloadSpec.Routes {
+
+			// Routes with header matches are told apart by their headers, plain routes only by their prefix
+			if r.Headers == nil {
+
+				if prefixes[*r.Prefix] {
+					return diag.FromErr(fmt.Errorf("route '%s' is defined more than once", *r.Prefix))
+				}
+
+				prefixes[*r.Prefix] = true
+
+				continue
 			}
 
-			if options.AutoScaling.MinScale != nil && *options.AutoScaling.MinScale != 1 {
-				return diag.FromErr(fmt.Errorf(errorMsg + "min scale must be set to 1 when workload type is 'cron'"))
+			for _, h := range *r.Headers {
+
+				matches := 0
+
+				for _, match := range []*string{h.Exact, h.Prefix, h.Regex} {
+					if match != nil {
+						matches++
+					}
+				}
+
+				if matches != 1 {
+					return diag.FromErr(fmt.Errorf("route '%s' - header '%s' must define exactly one of 'exact', 'prefix' or 'regex'", *r.Prefix, *h.Name))
+				}
+
+				if h.Regex != nil {
+					if _, err := regexp.Compile(*h.Regex); err != nil {
+						return diag.FromErr(fmt.Errorf("route '%s' - header '%s' has an invalid regex: %s", *r.Prefix, *h.Name, err))
+					}
+				}
 			}
+		}
+	}
+
+	if workloadSpec.Mirror != nil {
+
+		mirrors := map[string]bool{}
+
+		for _, m := range *workloadSpec.Mirror {
+
+			if mirrors[*m.WorkloadLink] {
+				return diag.FromErr(fmt.Errorf("mirror - workload '%s' is defined more than once", *m.WorkloadLink))
+			}
+
+			mirrors[*m.WorkloadLink] = true
+		}
+	}
+
+	return nil
+}
+
+func validateLoadBalancer(workloadSpec *client.WorkloadSpec) diag.Diagnostics {
 
-			if options.AutoScaling.MaxScale != nil && *options.AutoScaling.MaxScale != 1 {
-				return diag.FromErr(fmt.Errorf(errorMsg + "max scale must be set to 1 when workload type is 'cron'"))
+	if workloadSpec.LoadBalancer == nil || workloadSpec.LoadBalancer.Direct == nil {
+		return nil
+	}
+
+	direct := workloadSpec.LoadBalancer.Direct
+
+	if direct.Enabled == nil || !*direct.Enabled {
+		return nil
+	}
+
+	if *workloadSpec.Type == "cron" {
+		return diag.FromErr(fmt.Errorf("a direct load balancer is not allowed when workload type is 'cron'"))
+	}
+
+	if direct.Ports == nil {
+		return nil
+	}
+
+	// Collect the ports exposed by the containers
+	containerPorts := map[int]bool{}
+
+	if workloadSpec.Containers != nil {
+		for _, c := range *workloadSpec.Containers {
+
+			if c.Port != nil {
+				containerPorts[*c.Port] = true
 			}
-		} else {
-			if options.AutoScaling.Metric == nil || strings.TrimSpace(*options.AutoScaling.Metric) == "" {
-				return diag.FromErr(fmt.Errorf(errorMsg + "scaling strategy metric is required"))
+
+			if c.Ports != nil {
+				for _, p := range *c.Ports {
+					if p.Number != nil {
+						containerPorts[*p.Number] = true
+					}
+				}
 			}
 		}
 	}
 
+	externalPorts := map[string]bool{}
+
+	for _, p := range *direct.Ports {
+
+		key := fmt.Sprintf("%d/%s", *p.ExternalPort, *p.Protocol)
+
+		if externalPorts[key] {
+			return diag.FromErr(fmt.Errorf("load_balancer - external port %d is defined more than once for protocol %s", *p.ExternalPort, *p.Protocol))
+		}
+
+		externalPorts[key] = true
+
+		if p.ContainerPort != nil && !containerPorts[*p.ContainerPort] {
+			return diag.FromErr(fmt.Errorf("load_balancer - container port %d is not exposed by any container", *p.ContainerPort))
+		}
+	}
+
 	return nil
 }
@@ -1,8 +1,13 @@
 package cpln
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	client "github.com/controlplane-com/terraform-provider-cpln/internal/provider/client"
@@ -1687,6 +1692,87 @@ func TestControlPlane_BuildOptions(t *testing.T) {
 	}
 }
 
+func TestControlPlane_BuildOptionsScheduling(t *testing.T) {
+
+	unitTestWorkload := client.Workload{}
+	unitTestWorkload.Spec = &client.WorkloadSpec{}
+
+	_, expectedOptions, flatten := generateTestOptionsScheduling()
+	buildOptions(flatten, unitTestWorkload.Spec, true, "unit-test-org")
+
+	if diff := deep.Equal(unitTestWorkload.Spec.LocalOptions, &[]client.Options{*expectedOptions}); diff != nil {
+		t.Errorf("Scheduling options were not built correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_BuildKedaAutoScaling(t *testing.T) {
+	keda, expectedKeda, _ := generateTestKedaAutoScaling()
+	if diff := deep.Equal(keda, expectedKeda); diff != nil {
+		t.Errorf("Keda autoscaling was not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_ValidateOptions(t *testing.T) {
+
+	_, options, _ := generateTestOptionsScheduling()
+
+	if e := validateOptions("standard", "", options); e != nil {
+		t.Errorf("Options should be valid for a standard workload, got: %v", e)
+	}
+
+	if e := validateOptions("serverless", "", options); e == nil {
+		t.Errorf("Options should reject the 'multi' metric for a serverless workload")
+	}
+
+	if e := validateOptions("stateful", "", options); e == nil {
+		t.Errorf("Options should reject spot nodes for a stateful workload")
+	}
+
+	options.Spot = nil
+	(*options.NodeAffinity.Required)[0].Values = nil
+
+	if e := validateOptions("standard", "", options); e == nil {
+		t.Errorf("Options should require values for an 'In' node affinity expression")
+	}
+
+	(*options.NodeAffinity.Required)[0].Values = &[]string{"amd64"}
+	(*(*options.NodeAffinity.Preferred)[0].MatchExpressions)[0].Values = &[]string{"m5.large"}
+
+	if e := validateOptions("standard", "", options); e == nil {
+		t.Errorf("Options should reject values for an 'Exists' node affinity expression")
+	}
+
+	options.NodeAffinity = nil
+	options.AutoScaling.Multi = &[]client.MultiMetric{
+		{Metric: GetString("cpu"), Target: GetInt(50)},
+		{Metric: GetString("cpu"), Target: GetInt(70)},
+	}
+
+	if e := validateOptions("stateful", "", options); e == nil {
+		t.Errorf("Options should reject a duplicated multi metric")
+	}
+
+	options.AutoScaling.Multi = nil
+
+	if e := validateOptions("standard", "", options); e == nil {
+		t.Errorf("Options should require multi metrics when the metric is 'multi'")
+	}
+
+	options.AutoScaling.Metric = GetString("keda")
+	options.AutoScaling.Keda = &client.KedaAutoScaling{}
+
+	if e := validateOptions("standard", "", options); e == nil {
+		t.Errorf("Options should require at least one keda trigger when the metric is 'keda'")
+	}
+
+	cronOptions := generateTestOptions("cron")
+	cronOptions.MultiZone = &client.MultiZone{Enabled: GetBool(true)}
+
+	if e := validateOptions("cron", "", cronOptions); e == nil {
+		t.Errorf("Options should reject multi zone for a cron workload")
+	}
+}
+
 func TestControlPlane_BuildFirewallSpec(t *testing.T) {
 
 	unitTestWorkload := client.Workload{}
@@ -1739,7 +1825,906 @@ func TestControlPlane_BuildWorkloadSidecar(t *testing.T) {
 	}
 }
 
+func TestControlPlane_BuildWorkloadLoadBalancer(t *testing.T) {
+	loadBalancer, expectedLoadBalancer, _ := generateTestWorkloadLoadBalancer()
+	if diff := deep.Equal(loadBalancer, expectedLoadBalancer); diff != nil {
+		t.Errorf("Workload Load Balancer was not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_BuildWorkloadTraffic(t *testing.T) {
+
+	retryPolicy, routes, mirror, expectedRetryPolicy, expectedRoutes, expectedMirror, _, _, _ := generateTestWorkloadTraffic()
+
+	if diff := deep.Equal(retryPolicy, expectedRetryPolicy); diff != nil {
+		t.Errorf("Request retry policy was not built correctly, Diff: %s", diff)
+	}
+
+	if diff := deep.Equal(routes, expectedRoutes); diff != nil {
+		t.Errorf("Routes were not built correctly, Diff: %s", diff)
+	}
+
+	if diff := deep.Equal(mirror, expectedMirror); diff != nil {
+		t.Errorf("Mirror was not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_ValidateWorkloadTraffic(t *testing.T) {
+
+	_, _, _, retryPolicy, routes, mirror, _, _, _ := generateTestWorkloadTraffic()
+
+	workloadSpec := &client.WorkloadSpec{
+		Type:               GetString("standard"),
+		RequestRetryPolicy: retryPolicy,
+		Routes:             routes,
+		Mirror:             mirror,
+	}
+
+	if e := validateWorkloadTraffic(workloadSpec); e != nil {
+		t.Errorf("Workload traffic settings should be valid, got: %v", e)
+	}
+
+	workloadSpec.Type = GetString("cron")
+
+	if e := validateWorkloadTraffic(workloadSpec); e == nil {
+		t.Errorf("Workload traffic settings should not be allowed for cron workloads")
+	}
+
+	workloadSpec.Type = GetString("standard")
+	(*(*workloadSpec.Routes)[1].Headers)[0].Prefix = GetString("beta")
+
+	if e := validateWorkloadTraffic(workloadSpec); e == nil {
+		t.Errorf("A route header should not define more than one match")
+	}
+
+	(*(*workloadSpec.Routes)[1].Headers)[0].Prefix = nil
+	*workloadSpec.Routes = append(*workloadSpec.Routes, client.WorkloadRoute{Prefix: GetString("/api")})
+
+	if e := validateWorkloadTraffic(workloadSpec); e == nil {
+		t.Errorf("A route without headers should not be defined more than once")
+	}
+}
+
+func TestControlPlane_ParseQuantity(t *testing.T) {
+
+	quantities := map[string]float64{
+		"50m":   0.05,
+		"0.5":   0.5,
+		"2":     2,
+		"1500m": 1.5,
+		"128Mi": 128 * (1 << 20),
+		"1.5Gi": 1.5 * (1 << 30),
+		"7G":    7e9,
+		"1Ti":   1 << 40,
+		"1e3":   1000,
+		"2k":    2000,
+	}
+
+	for value, expected := range quantities {
+
+		quantity, err := ParseQuantity(value)
+
+		if err != nil {
+			t.Errorf("Quantity %s should be valid, got: %v", value, err)
+			continue
+		}
+
+		if math.Abs(quantity-expected) > 1e-9*math.Max(1, expected) {
+			t.Errorf("Quantity %s should equal %v, got: %v", value, expected, quantity)
+		}
+	}
+
+	for _, value := range []string{"", "Gi", "1.2.3", "1Gb", "1 Gi", "1e"} {
+		if _, err := ParseQuantity(value); err == nil {
+			t.Errorf("Quantity %q should be invalid", value)
+		}
+	}
+}
+
+func TestControlPlane_ValidateContainerResources(t *testing.T) {
+
+	workloadSpec := &client.WorkloadSpec{
+		Type: GetString("standard"),
+		Containers: &[]client.ContainerSpec{
+			{
+				Name:      GetString("app"),
+				CPU:       GetString("1"),
+				Memory:    GetString("1Gi"),
+				MinCPU:    GetString("250m"),
+				MinMemory: GetString("512Mi"),
+			},
+		},
+		DefaultOptions: &client.Options{
+			CapacityAI: GetBool(false),
+		},
+	}
+
+	if err := validateContainerResources(workloadSpec); err != nil {
+		t.Errorf("Container resources should be valid, got: %v", err)
+	}
+
+	(*workloadSpec.Containers)[0].MinMemory = GetString("1.5Gi")
+
+	if err := validateContainerResources(workloadSpec); err == nil {
+		t.Errorf("min_memory should not be allowed to exceed memory")
+	}
+
+	(*workloadSpec.Containers)[0].MinMemory = GetString("1024Mi")
+	(*workloadSpec.Containers)[0].MinCPU = GetString("1.5")
+
+	if err := validateContainerResources(workloadSpec); err == nil {
+		t.Errorf("min_cpu should not be allowed to exceed cpu")
+	}
+
+	(*workloadSpec.Containers)[0].MinCPU = GetString("1000m")
+	workloadSpec.DefaultOptions.CapacityAI = GetBool(true)
+
+	if err := validateContainerResources(workloadSpec); err == nil {
+		t.Errorf("Container minimums should not be allowed when capacity AI is enabled")
+	}
+
+	workloadSpec.DefaultOptions.CapacityAI = GetBool(false)
+	workloadSpec.LocalOptions = &[]client.Options{
+		{
+			Location:   GetString("/org/default/location/aws-eu-central-1"),
+			CapacityAI: GetBool(true),
+		},
+	}
+
+	if err := validateContainerResources(workloadSpec); err == nil {
+		t.Errorf("Container minimums should not be allowed when capacity AI is enabled in a location")
+	}
+
+	workloadSpec.LocalOptions = nil
+
+	(*workloadSpec.Containers)[0].MinCPU = nil
+	(*workloadSpec.Containers)[0].MinMemory = nil
+	(*workloadSpec.Containers)[0].CPU = GetString("2000m")
+	(*workloadSpec.Containers)[0].Memory = GetString("7Gi")
+	(*workloadSpec.Containers)[0].GPU = &client.GpuResource{
+		Nvidia: &client.Nvidia{
+			Model:    GetString("t4"),
+			Quantity: GetInt(1),
+		},
+	}
+
+	if err := validateContainerResources(workloadSpec); err != nil {
+		t.Errorf("GPU container resources should be valid, got: %v", err)
+	}
+
+	for _, memory := range []string{"8G", "7168Mi", "0.5Ti", "7516192768"} {
+
+		(*workloadSpec.Containers)[0].Memory = GetString(memory)
+
+		if err := validateContainerResources(workloadSpec); err != nil {
+			t.Errorf("%s should satisfy the GPU memory minimum, got: %v", memory, err)
+		}
+	}
+
+	for _, memory := range []string{"6Gi", "7000Mi", "7G", "6000M", "1G", "7000000000"} {
+
+		(*workloadSpec.Containers)[0].Memory = GetString(memory)
+
+		if err := validateContainerResources(workloadSpec); err == nil {
+			t.Errorf("%s should not satisfy the GPU memory minimum", memory)
+		}
+	}
+
+	(*workloadSpec.Containers)[0].Memory = GetString("7Gi")
+
+	for _, cpu := range []string{"1.9", "1999m", "1"} {
+
+		(*workloadSpec.Containers)[0].CPU = GetString(cpu)
+
+		if err := validateContainerResources(workloadSpec); err == nil {
+			t.Errorf("%s should not satisfy the GPU CPU minimum", cpu)
+		}
+	}
+
+	(*workloadSpec.Containers)[0].CPU = GetString("2")
+
+	(*workloadSpec.Containers)[0].Memory = GetString("7Gi")
+	workloadSpec.LocalOptions = &[]client.Options{
+		{
+			Location:   GetString("/org/default/location/aws-eu-central-1"),
+			CapacityAI: GetBool(true),
+		},
+	}
+
+	if err := validateContainerResources(workloadSpec); err == nil {
+		t.Errorf("Capacity AI should not be allowed in any location when using GPUs")
+	}
+}
+
+func TestControlPlane_ValidateLoadBalancer(t *testing.T) {
+
+	loadBalancer, _, _ := generateTestWorkloadLoadBalancer()
+
+	workloadSpec := &client.WorkloadSpec{
+		Type:         GetString("standard"),
+		Containers:   generateTestContainers("standard"),
+		LoadBalancer: loadBalancer,
+	}
+
+	if e := validateLoadBalancer(workloadSpec); e != nil {
+		t.Errorf("Workload Load Balancer should be valid, got: %v", e)
+	}
+
+	workloadSpec.Type = GetString("cron")
+
+	if e := validateLoadBalancer(workloadSpec); e == nil {
+		t.Errorf("Workload Load Balancer should not be allowed for cron workloads")
+	}
+
+	workloadSpec.Type = GetString("standard")
+	(*workloadSpec.LoadBalancer.Direct.Ports)[0].ContainerPort = GetInt(9999)
+
+	if e := validateLoadBalancer(workloadSpec); e == nil {
+		t.Errorf("Workload Load Balancer should reject a container port that is not exposed")
+	}
+}
+
+func TestControlPlane_BuildEnvFrom(t *testing.T) {
+	envFrom, expectedEnvFrom, _ := generateTestEnvFrom()
+	if diff := deep.Equal(envFrom, expectedEnvFrom); diff != nil {
+		t.Errorf("Env from was not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_BuildInitContainers(t *testing.T) {
+	initContainers, expectedInitContainers, _ := generateTestInitContainers()
+	if diff := deep.Equal(initContainers, expectedInitContainers); diff != nil {
+		t.Errorf("Init containers were not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_ValidateInitContainers(t *testing.T) {
+
+	_, initContainers, _ := generateTestInitContainers()
+
+	workloadSpec := &client.WorkloadSpec{
+		Type:           GetString("standard"),
+		Containers:     generateTestContainers("standard"),
+		InitContainers: initContainers,
+	}
+
+	if err := validateInitContainers(workloadSpec); err != nil {
+		t.Errorf("Init containers should be valid, got: %s", err)
+	}
+
+	(*workloadSpec.InitContainers)[0].Name = (*workloadSpec.Containers)[0].Name
+
+	if err := validateInitContainers(workloadSpec); err == nil {
+		t.Errorf("An init container should not share its name with a container")
+	}
+
+	(*workloadSpec.InitContainers)[0].Name = GetString("migrate")
+	(*(*workloadSpec.InitContainers)[0].Volumes)[0].Uri = GetString("cpln://volumeset/data")
+
+	if err := validateInitContainers(workloadSpec); err == nil {
+		t.Errorf("An init container should not mount a volume set when workload type is not stateful")
+	}
+
+	workloadSpec.Type = GetString("stateful")
+
+	if err := validateInitContainers(workloadSpec); err != nil {
+		t.Errorf("An init container should be able to mount a volume set when workload type is stateful, got: %s", err)
+	}
+}
+
+func TestControlPlane_ValidateEnvFrom(t *testing.T) {
+
+	_, _, flatten := generateTestEnvFrom()
+
+	container := map[string]interface{}{
+		"name":        "container-01",
+		"inherit_env": true,
+		"env": map[string]interface{}{
+			"PORT": "8080",
+		},
+		"env_from": flatten,
+	}
+
+	if err := validateEnvFrom([]interface{}{container}); err != nil {
+		t.Errorf("Env from should be valid, got: %s", err)
+	}
+
+	container["inherit_env"] = false
+
+	if err := validateEnvFrom([]interface{}{container}); err == nil {
+		t.Errorf("Env from should require inherit_env when referencing a GVC env variable")
+	}
+
+	container["inherit_env"] = true
+	container["env"] = map[string]interface{}{
+		"DB_PASSWORD": "plain",
+	}
+
+	if err := validateEnvFrom([]interface{}{container}); err == nil {
+		t.Errorf("Env from should reject a name that is already defined in env")
+	}
+
+	container["env"] = nil
+	flatten[0].(map[string]interface{})["gvc_env"] = []interface{}{
+		map[string]interface{}{
+			"name": "REGION",
+		},
+	}
+
+	if err := validateEnvFrom([]interface{}{container}); err == nil {
+		t.Errorf("Env from should reject an entry with more than one source")
+	}
+}
+
+func TestControlPlane_HasSecretRevealPolicy(t *testing.T) {
+
+	org := "unit-test-org"
+	identityLink := "/org/unit-test-org/gvc/gvc-01/identity/identity-01"
+
+	policies := []client.Policy{
+		{
+			TargetKind:  GetString("secret"),
+			TargetLinks: &[]string{"/org/unit-test-org/secret/db"},
+			Bindings: &[]client.Binding{
+				{
+					Permissions:    &[]string{"reveal"},
+					PrincipalLinks: &[]string{identityLink},
+				},
+			},
+		},
+		{
+			TargetKind:  GetString("secret"),
+			TargetLinks: &[]string{"/org/unit-test-org/secret/api"},
+			Bindings: &[]client.Binding{
+				{
+					Permissions:    &[]string{"view"},
+					PrincipalLinks: &[]string{identityLink},
+				},
+			},
+		},
+	}
+
+	if !hasSecretRevealPolicy(policies, org, "gvc-01", "db", nil, identityLink) {
+		t.Errorf("Identity should be able to reveal secret 'db'")
+	}
+
+	if hasSecretRevealPolicy(policies, org, "gvc-01", "api", nil, identityLink) {
+		t.Errorf("Identity should not be able to reveal secret 'api', only 'view' is granted")
+	}
+
+	if hasSecretRevealPolicy(policies, org, "gvc-01", "other", nil, identityLink) {
+		t.Errorf("Identity should not be able to reveal a secret that is not targeted")
+	}
+
+	policies[1].Target = GetString("all")
+	policies[1].TargetLinks = nil
+	(*policies[1].Bindings)[0].Permissions = &[]string{"manage"}
+
+	if !hasSecretRevealPolicy(policies, org, "gvc-01", "other", nil, identityLink) {
+		t.Errorf("Identity should be able to reveal any secret when a policy targets all secrets with 'manage'")
+	}
+
+	// An identity with the same name in another GVC is a different principal
+	if hasSecretRevealPolicy(policies, org, "gvc-01", "db", nil, "/org/unit-test-org/gvc/gvc-02/identity/identity-01") {
+		t.Errorf("Identity of another GVC should not be able to reveal secret 'db'")
+	}
+
+	(*policies[0].Bindings)[0].PrincipalLinks = &[]string{"//gvc/gvc-01/identity/identity-01"}
+
+	if !hasSecretRevealPolicy(policies, org, "gvc-01", "db", nil, identityLink) {
+		t.Errorf("Identity should be able to reveal secret 'db' when the principal is given as a relative link")
+	}
+
+	(*policies[0].Bindings)[0].PrincipalLinks = &[]string{"//identity/identity-01"}
+
+	if !hasSecretRevealPolicy(policies[:1], org, "gvc-01", "db", nil, identityLink) {
+		t.Errorf("Identity should be able to reveal secret 'db' when the principal is relative to the workload GVC")
+	}
+
+	if hasSecretRevealPolicy(policies[:1], org, "gvc-02", "db", nil, identityLink) {
+		t.Errorf("A principal relative to another GVC should not match the identity")
+	}
+
+	// A policy targeting secrets by query only counts when the query matches the secret
+	policies = []client.Policy{
+		{
+			TargetKind: GetString("secret"),
+			TargetQuery: &client.Query{
+				Spec: &client.Spec{
+					Match: GetString("all"),
+					Terms: &[]client.Term{
+						{
+							Op:    GetString("="),
+							Tag:   GetString("team"),
+							Value: GetString("payments"),
+						},
+					},
+				},
+			},
+			Bindings: &[]client.Binding{
+				{
+					Permissions:    &[]string{"reveal"},
+					PrincipalLinks: &[]string{identityLink},
+				},
+			},
+		},
+	}
+
+	tagged := &client.Secret{Base: client.Base{Tags: &map[string]interface{}{"team": "payments"}}}
+	untagged := &client.Secret{Base: client.Base{Tags: &map[string]interface{}{"team": "search"}}}
+
+	if !hasSecretRevealPolicy(policies, org, "gvc-01", "db", tagged, identityLink) {
+		t.Errorf("Identity should be able to reveal a secret matched by the policy query")
+	}
+
+	if hasSecretRevealPolicy(policies, org, "gvc-01", "db", untagged, identityLink) {
+		t.Errorf("Identity should not be able to reveal a secret that the policy query does not match")
+	}
+}
+
+func TestControlPlane_SecretMatchesQuery(t *testing.T) {
+
+	secret := &client.Secret{Base: client.Base{Tags: &map[string]interface{}{"team": "payments", "tier": 1}}}
+
+	query := func(match string, terms ...client.Term) *client.Query {
+		return &client.Query{Spec: &client.Spec{Match: GetString(match), Terms: &terms}}
+	}
+
+	tests := []struct {
+		name     string
+		query    *client.Query
+		expected bool
+	}{
+		{"nil query", nil, false},
+		{"no terms", query("all"), false},
+		{"tag equals", query("all", client.Term{Op: GetString("="), Tag: GetString("team"), Value: GetString("payments")}), true},
+		{"numeric tag equals", query("all", client.Term{Op: GetString("="), Tag: GetString("tier"), Value: GetString("1")}), true},
+		{"tag not equals", query("all", client.Term{Op: GetString("!="), Tag: GetString("team"), Value: GetString("payments")}), false},
+		{"tag exists", query("all", client.Term{Op: GetString("exists"), Tag: GetString("team")}), true},
+		{"tag not exists", query("all", client.Term{Op: GetString("!exists"), Tag: GetString("owner")}), true},
+		{"name property", query("all", client.Term{Op: GetString("="), Property: GetString("name"), Value: GetString("db")}), true},
+		{"all with a mismatch", query("all",
+			client.Term{Op: GetString("="), Tag: GetString("team"), Value: GetString("payments")},
+			client.Term{Op: GetString("="), Property: GetString("name"), Value: GetString("api")}), false},
+		{"any with a mismatch", query("any",
+			client.Term{Op: GetString("="), Tag: GetString("team"), Value: GetString("payments")},
+			client.Term{Op: GetString("="), Property: GetString("name"), Value: GetString("api")}), true},
+		{"none", query("none", client.Term{Op: GetString("="), Tag: GetString("team"), Value: GetString("search")}), true},
+		{"unsupported operator", query("all", client.Term{Op: GetString("~"), Tag: GetString("team"), Value: GetString("pay")}), false},
+		{"unsupported property", query("all", client.Term{Op: GetString("="), Property: GetString("description"), Value: GetString("db")}), false},
+	}
+
+	for _, test := range tests {
+		if output := secretMatchesQuery(test.query, "db", secret); output != test.expected {
+			t.Errorf("Query '%s' evaluated to %t, expected %t", test.name, output, test.expected)
+		}
+	}
+}
+
+func TestControlPlane_ValidateEnvFromSecrets(t *testing.T) {
+
+	policies := `{"kind":"list","items":[]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/unit-test-org/secret/db":
+			w.Write([]byte(`{"name":"db","tags":{"team":"payments"}}`))
+		case "/org/unit-test-org/policy":
+			w.Write([]byte(policies))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client.Client{
+		HostURL:    server.URL,
+		Org:        "unit-test-org",
+		HTTPClient: server.Client(),
+	}
+
+	containers := func(secretName string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"env_from": []interface{}{
+					map[string]interface{}{
+						"secret": []interface{}{
+							map[string]interface{}{
+								"name": secretName,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if err := validateEnvFromSecrets(c, "gvc-01", containers("missing"), "//identity/identity-01"); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("A missing secret should be rejected, got: %v", err)
+	}
+
+	if err := validateEnvFromSecrets(c, "gvc-01", containers("db"), "//identity/identity-01"); err == nil || !strings.Contains(err.Error(), "reveal") {
+		t.Errorf("A secret without a reveal policy should be rejected, got: %v", err)
+	}
+
+	policies = `{"kind":"list","items":[{"name":"reveal","targetKind":"secret","targetQuery":{"spec":{"match":"all","terms":[{"op":"=","tag":"team","value":"payments"}]}},"bindings":[{"permissions":["reveal"],"principalLinks":["//identity/identity-01"]}]}]}`
+
+	if err := validateEnvFromSecrets(c, "gvc-01", containers("db"), "//identity/identity-01"); err != nil {
+		t.Errorf("A secret revealed by a policy query should be accepted, got: %s", err)
+	}
+
+	// Secret names unknown during plan are skipped
+	if err := validateEnvFromSecrets(c, "gvc-01", containers(""), "//identity/identity-01"); err != nil {
+		t.Errorf("Unknown secret names should not be checked, got: %s", err)
+	}
+}
+
+func TestControlPlane_GetFullIdentityLink(t *testing.T) {
+
+	expected := "/org/unit-test-org/gvc/gvc-01/identity/identity-01"
+
+	for _, link := range []string{expected, "//identity/identity-01", "//gvc/gvc-01/identity/identity-01"} {
+		if output := getFullIdentityLink("unit-test-org", "gvc-01", link); output != expected {
+			t.Errorf("Identity link '%s' was not expanded correctly, got: %s", link, output)
+		}
+	}
+}
+
+func TestControlPlane_BuildTypedVolumes(t *testing.T) {
+	volumes, expectedVolumes, _ := generateTestTypedVolumes()
+	if diff := deep.Equal(volumes, expectedVolumes); diff != nil {
+		t.Errorf("Typed volumes were not built correctly, Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_ValidateWorkloadVolumes(t *testing.T) {
+
+	workloadSpec := &client.WorkloadSpec{
+		Type: GetString("stateful"),
+		Containers: &[]client.ContainerSpec{
+			{
+				Name: GetString("container-01"),
+				Volumes: &[]client.VolumeSpec{
+					{
+						Uri:  GetString("cpln://volumeset/data"),
+						Path: GetString("/data"),
+					},
+				},
+			},
+		},
+	}
+
+	if e := validateWorkloadVolumes(workloadSpec); e != nil {
+		t.Errorf("Volume set should be allowed for a stateful workload, got: %v", e)
+	}
+
+	if diff := deep.Equal(getWorkloadVolumeSetNames(workloadSpec), []string{"data"}); diff != nil {
+		t.Errorf("Volume set names were not extracted correctly. Diff: %s", diff)
+	}
+
+	workloadSpec.InitContainers = &[]client.ContainerSpec{
+		{
+			Name: GetString("init-01"),
+			Volumes: &[]client.VolumeSpec{
+				{
+					Uri:  GetString("cpln://volumeset/data"),
+					Path: GetString("/data"),
+				},
+				{
+					Uri:  GetString("cpln://volumeset/seed"),
+					Path: GetString("/seed"),
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(getWorkloadVolumeSetNames(workloadSpec), []string{"data", "seed"}); diff != nil {
+		t.Errorf("Volume set names of the init containers were not extracted correctly. Diff: %s", diff)
+	}
+
+	workloadSpec.InitContainers = nil
+
+	workloadSpec.Type = GetString("standard")
+
+	if e := validateWorkloadVolumes(workloadSpec); e == nil {
+		t.Errorf("Volume set should not be allowed for a standard workload")
+	}
+
+	workloadSpec.Type = GetString("stateful")
+	*workloadSpec.Containers = append(*workloadSpec.Containers, client.ContainerSpec{
+		Name: GetString("container-02"),
+		Volumes: &[]client.VolumeSpec{
+			{
+				Uri:  GetString("cpln://volumeset/data"),
+				Path: GetString("/backup"),
+			},
+		},
+	})
+
+	if e := validateWorkloadVolumes(workloadSpec); e == nil {
+		t.Errorf("Volume set should not be mounted more than once per workload")
+	}
+}
+
 // Flatten //
+func TestControlPlane_FlattenInitContainers(t *testing.T) {
+
+	_, expectedInitContainers, expectedFlatten := generateTestInitContainers()
+	flattenedInitContainers := flattenInitContainers(expectedInitContainers)
+
+	if diff := deep.Equal(expectedFlatten, flattenedInitContainers); diff != nil {
+		t.Errorf("Init containers were not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenEnvFrom(t *testing.T) {
+
+	_, expectedEnvFrom, expectedFlatten := generateTestEnvFrom()
+	flattenedEnvFrom := []interface{}{}
+
+	for _, env := range expectedEnvFrom {
+		flattenedEnvFrom = append(flattenedEnvFrom, flattenEnvFrom(env))
+	}
+
+	if diff := deep.Equal(expectedFlatten, flattenedEnvFrom); diff != nil {
+		t.Errorf("Env from was not flattened correctly. Diff: %s", diff)
+	}
+
+	if flattenEnvFrom(client.NameValue{Name: GetString("PORT"), Value: GetString("8080")}) != nil {
+		t.Errorf("A plain env value should not be flattened into env_from")
+	}
+}
+
+func TestControlPlane_FlattenTypedVolumes(t *testing.T) {
+
+	_, expectedVolumes, expectedFlatten := generateTestTypedVolumes()
+
+	rawUri := "s3://raw-bucket"
+	rawPath := "/raw"
+	rawRecoveryPolicy := "retain"
+
+	containers := []client.ContainerSpec{
+		{
+			Name:    GetString("container-01"),
+			Image:   GetString("nginx"),
+			CPU:     GetString("50m"),
+			Memory:  GetString("128Mi"),
+			Volumes: &[]client.VolumeSpec{},
+		},
+	}
+
+	*containers[0].Volumes = append(*containers[0].Volumes, client.VolumeSpec{Uri: &rawUri, Path: &rawPath, RecoveryPolicy: &rawRecoveryPolicy})
+	*containers[0].Volumes = append(*containers[0].Volumes, expectedVolumes...)
+
+	typedVolumePaths := getTypedVolumePaths([]interface{}{expectedFlatten})
+	flattenedContainer := flattenContainer(&containers, false, typedVolumePaths, nil)[0].(map[string]interface{})
+
+	expectedRawVolumes := []interface{}{
+		map[string]interface{}{
+			"uri":             rawUri,
+			"path":            rawPath,
+			"recovery_policy": rawRecoveryPolicy,
+		},
+	}
+
+	if diff := deep.Equal(expectedRawVolumes, flattenedContainer["volume"]); diff != nil {
+		t.Errorf("Raw volumes were not flattened correctly. Diff: %s", diff)
+	}
+
+	for _, block := range []string{"secret_volume", "volumeset_volume", "s3_volume", "gcs_volume", "azure_blob_volume", "scratch_volume"} {
+		if diff := deep.Equal(expectedFlatten[block], flattenedContainer[block]); diff != nil {
+			t.Errorf("Typed volume block %s was not flattened correctly. Diff: %s", block, diff)
+		}
+	}
+
+	// Without a typed declaration, volumes are flattened into the raw form
+	flattenedContainer = flattenContainer(&containers, false, nil, nil)[0].(map[string]interface{})
+
+	if volumes := flattenedContainer["volume"].([]interface{}); len(volumes) != 7 {
+		t.Errorf("All volumes should have been flattened into the raw form, got: %d", len(volumes))
+	}
+}
+
+// Create validates the whole spec, as update always did, so a rule rejected on update is also rejected for a new workload
+func TestControlPlane_CreateWorkloadValidatesSpec(t *testing.T) {
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c := &client.Client{
+		HostURL:    server.URL,
+		Org:        "unit-test-org",
+		HTTPClient: server.Client(),
+	}
+
+	container := map[string]interface{}{
+		"name":   "container-01",
+		"image":  "nginx",
+		"cpu":    "50m",
+		"memory": "128Mi",
+		"ports": []interface{}{
+			map[string]interface{}{
+				"number":   80,
+				"protocol": "http",
+			},
+		},
+	}
+
+	options := func(capacityAI bool, scale int) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"capacity_ai": capacityAI,
+				"autoscaling": []interface{}{
+					map[string]interface{}{
+						"min_scale": scale,
+						"max_scale": scale,
+					},
+				},
+			},
+		}
+	}
+
+	rawConfigs := map[string]map[string]interface{}{
+		"rollout options": {
+			"name":      "workload-01",
+			"gvc":       "gvc-01",
+			"type":      "serverless",
+			"container": []interface{}{container},
+			"options":   options(true, 1),
+			"rollout_options": []interface{}{
+				map[string]interface{}{
+					"min_ready_seconds": 2,
+				},
+			},
+		},
+		"direct load balancer": {
+			"name":      "workload-01",
+			"gvc":       "gvc-01",
+			"type":      "cron",
+			"container": []interface{}{container},
+			"options":   options(false, 1),
+			"job": []interface{}{
+				map[string]interface{}{
+					"schedule": "* * * * *",
+				},
+			},
+			"load_balancer": []interface{}{
+				map[string]interface{}{
+					"direct": []interface{}{
+						map[string]interface{}{
+							"enabled": true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for rule, raw := range rawConfigs {
+
+		d := schema.TestResourceDataRaw(t, resourceWorkload().Schema, raw)
+		diags := resourceWorkloadCreate(context.Background(), d, c)
+
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, rule) {
+			t.Errorf("Create should reject the %s rule, got: %v", rule, diags)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("Create should reject an invalid spec before calling the API, got %d requests", requests)
+	}
+}
+
+func TestControlPlane_ValidateWorkloadVolumeSets(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		switch r.URL.Path {
+		case "/org/unit-test-org/gvc/unit-test-gvc/volumeset/owned":
+			w.Write([]byte(`{"name":"owned","status":{"usedByWorkload":"/org/unit-test-org/gvc/unit-test-gvc/workload/other"}}`))
+		case "/org/unit-test-org/gvc/unit-test-gvc/volumeset/free":
+			w.Write([]byte(`{"name":"free","status":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := &client.Client{
+		HostURL:    server.URL,
+		Org:        "unit-test-org",
+		HTTPClient: server.Client(),
+	}
+
+	workloadSpec := func(volumeSetName string) *client.WorkloadSpec {
+		return &client.WorkloadSpec{
+			Containers: &[]client.ContainerSpec{
+				{
+					Volumes: &[]client.VolumeSpec{
+						{
+							Uri:  GetString("cpln://volumeset/" + volumeSetName),
+							Path: GetString("/data"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if e := validateWorkloadVolumeSets(c, "unit-test-gvc", "workload", workloadSpec("free")); e != nil {
+		t.Errorf("An unused volume set should be allowed, got: %v", e)
+	}
+
+	if e := validateWorkloadVolumeSets(c, "unit-test-gvc", "workload", workloadSpec("missing")); e != nil {
+		t.Errorf("A volume set that is not created yet should be allowed at plan time, got: %v", e)
+	}
+
+	if e := validateWorkloadVolumeSets(c, "unit-test-gvc", "other", workloadSpec("owned")); e != nil {
+		t.Errorf("A volume set should be allowed for the workload that uses it, got: %v", e)
+	}
+
+	if e := validateWorkloadVolumeSets(c, "unit-test-gvc", "workload", workloadSpec("owned")); e == nil {
+		t.Errorf("A volume set used by another workload should be rejected")
+	}
+}
+
+func TestControlPlane_FlattenWorkloadVolumeStatus(t *testing.T) {
+
+	volumeSetName := "data"
+	locationName := "/org/unit-test-org/location/aws-eu-central-1"
+	lifecycle := "bound"
+	zone := "eu-central-1a"
+	index := 0
+	currentSize := 10
+	currentBytesUsed := int64(1024)
+
+	volumeSets := []client.VolumeSet{
+		{
+			Base: client.Base{
+				Name: &volumeSetName,
+			},
+			Status: &client.VolumeSetStatus{
+				Locations: &[]client.VolumeSetLocation{
+					{
+						Name: &locationName,
+						Volumes: &[]client.VolumeSetVolume{
+							{
+								Index:            &index,
+								Lifecycle:        &lifecycle,
+								Zone:             &zone,
+								CurrentSize:      &currentSize,
+								CurrentBytesUsed: &currentBytesUsed,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expectedFlatten := []interface{}{
+		map[string]interface{}{
+			"volume_set":         volumeSetName,
+			"location":           "aws-eu-central-1",
+			"replica":            index,
+			"lifecycle":          lifecycle,
+			"zone":               zone,
+			"current_size":       currentSize,
+			"current_bytes_used": 1024,
+		},
+	}
+
+	if diff := deep.Equal(expectedFlatten, flattenWorkloadVolumeStatus(volumeSets)); diff != nil {
+		t.Errorf("Workload volume status was not flattened correctly. Diff: %s", diff)
+	}
+}
+
 func TestControlPlane_FlattenWorkloadStatus(t *testing.T) {
 
 	endpoint := "endpoint"
@@ -1772,7 +2757,7 @@ func TestControlPlane_FlattenWorkloadStatus(t *testing.T) {
 func TestControlPlane_FlattenContainerServerless(t *testing.T) {
 
 	containers := generateTestContainers("serverless")
-	flattenedContainer := flattenContainer(containers, false)
+	flattenedContainer := flattenContainer(containers, false, nil, nil)
 
 	flatContainer := generateFlatTestContainer("serverless")
 
@@ -1784,7 +2769,7 @@ func TestControlPlane_FlattenContainerServerless(t *testing.T) {
 func TestControlPlane_FlattenContainerStandard(t *testing.T) {
 
 	containers := generateTestContainers("standard")
-	flattenedContainer := flattenContainer(containers, false)
+	flattenedContainer := flattenContainer(containers, false, nil, nil)
 
 	flatContainer := generateFlatTestContainer("standard")
 
@@ -1796,7 +2781,7 @@ func TestControlPlane_FlattenContainerStandard(t *testing.T) {
 func TestControlPlane_FlattenContainerReadinessGrpc(t *testing.T) {
 
 	containers := generateTestContainers("standard-readiness-grpc")
-	flattenedContainer := flattenContainer(containers, false)
+	flattenedContainer := flattenContainer(containers, false, nil, nil)
 
 	flatContainer := generateFlatTestContainer("standard-readiness-grpc")
 
@@ -1824,6 +2809,25 @@ func TestControlPlane_FlattenOptions(t *testing.T) {
 	}
 }
 
+func TestControlPlane_FlattenOptionsScheduling(t *testing.T) {
+
+	_, expectedOptions, expectedFlatten := generateTestOptionsScheduling()
+	flattenedOptions := flattenOptions([]client.Options{*expectedOptions}, true, "unit-test-org")
+
+	if diff := deep.Equal(expectedFlatten, flattenedOptions); diff != nil {
+		t.Errorf("Scheduling options were not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenKedaAutoScaling(t *testing.T) {
+	_, expectedKeda, expectedFlatten := generateTestKedaAutoScaling()
+	flattenedKeda := flattenKedaAutoScaling(expectedKeda)
+
+	if diff := deep.Equal(expectedFlatten, flattenedKeda); diff != nil {
+		t.Errorf("Keda autoscaling was not flattened correctly. Diff: %s", diff)
+	}
+}
+
 func TestControlPlane_FlattenFirewallSpec(t *testing.T) {
 
 	spec := generateTestFirewallSpec("")
@@ -1853,14 +2857,75 @@ func TestControlPlane_FlattenSecurityOptions(t *testing.T) {
 	if diff := deep.Equal(expectedFlatten, flattenSecurityOptions); diff != nil {
 		t.Errorf("SecurityOptions was not flattened correctly. Diff: %s", diff)
 	}
-}
+}
+
+func TestControlPlane_FlattenWorkloadSidecar(t *testing.T) {
+	_, expectedSidecar, expectedFlatten := generateTestWorkloadSidecar(workloadEnvoyJson)
+	flattenSidecar := flattenWorkloadSidecar(expectedSidecar)
+
+	if diff := deep.Equal(expectedFlatten, flattenSidecar); diff != nil {
+		t.Errorf("Workload Sidecar was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenWorkloadTraffic(t *testing.T) {
+
+	_, _, _, expectedRetryPolicy, expectedRoutes, expectedMirror, flatRetryPolicy, flatRoutes, flatMirror := generateTestWorkloadTraffic()
+
+	// retry_on is a set in the schema, flattened as a list
+	flatRetryPolicy[0].(map[string]interface{})["retry_on"] = []interface{}{"5xx", "reset"}
+
+	if diff := deep.Equal(flatRetryPolicy, flattenRequestRetryPolicy(expectedRetryPolicy)); diff != nil {
+		t.Errorf("Request retry policy was not flattened correctly. Diff: %s", diff)
+	}
+
+	if diff := deep.Equal(flatRoutes, flattenWorkloadRoutes(expectedRoutes)); diff != nil {
+		t.Errorf("Routes were not flattened correctly. Diff: %s", diff)
+	}
+
+	if diff := deep.Equal(flatMirror, flattenWorkloadMirror(expectedMirror)); diff != nil {
+		t.Errorf("Mirror was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenWorkloadLoadBalancer(t *testing.T) {
+	_, expectedLoadBalancer, expectedFlatten := generateTestWorkloadLoadBalancer()
+	flattenLoadBalancer := flattenWorkloadLoadBalancer(expectedLoadBalancer)
+
+	if diff := deep.Equal(expectedFlatten, flattenLoadBalancer); diff != nil {
+		t.Errorf("Workload Load Balancer was not flattened correctly. Diff: %s", diff)
+	}
+}
+
+func TestControlPlane_FlattenWorkloadStatusLoadBalancer(t *testing.T) {
+
+	origin := "34.120.10.10"
+	url := "tcp://34.120.10.10:1883"
+
+	status := &client.WorkloadStatus{
+		LoadBalancer: &[]client.LoadBalancerStatus{
+			{
+				Origin:      &origin,
+				Url:         &url,
+				IpAddresses: &[]string{"34.120.10.10", "34.120.10.11"},
+			},
+		},
+	}
 
-func TestControlPlane_FlattenWorkloadSidecar(t *testing.T) {
-	_, expectedSidecar, expectedFlatten := generateTestWorkloadSidecar(workloadEnvoyJson)
-	flattenSidecar := flattenWorkloadSidecar(expectedSidecar)
+	expectedFlatten := []interface{}{
+		map[string]interface{}{
+			"load_balancer": []interface{}{
+				map[string]interface{}{
+					"origin":       origin,
+					"url":          url,
+					"ip_addresses": []interface{}{"34.120.10.10", "34.120.10.11"},
+				},
+			},
+		},
+	}
 
-	if diff := deep.Equal(expectedFlatten, flattenSidecar); diff != nil {
-		t.Errorf("Workload Sidecar was not flattened correctly. Diff: %s", diff)
+	if diff := deep.Equal(expectedFlatten, flattenWorkloadStatus(status)); diff != nil {
+		t.Errorf("Workload Status Load Balancer was not flattened correctly. Diff: %s", diff)
 	}
 }
 
@@ -2125,6 +3190,391 @@ func generateTestOptions(workloadType string) *client.Options {
 	}
 }
 
+func generateTestOptionsScheduling() (*client.Options, *client.Options, []interface{}) {
+
+	org := "unit-test-org"
+	location := "aws-eu-central-1"
+	locationLink := fmt.Sprintf("/org/%s/location/%s", org, location)
+
+	flatten := generateFlatTestOptionsScheduling(location)
+
+	unitTestWorkload := client.Workload{}
+	unitTestWorkload.Spec = &client.WorkloadSpec{}
+	buildOptions(flatten, unitTestWorkload.Spec, true, org)
+
+	expectedOptions := &client.Options{
+		Location:       &locationLink,
+		CapacityAI:     GetBool(false),
+		TimeoutSeconds: GetInt(30),
+		Debug:          GetBool(false),
+		Suspend:        GetBool(false),
+		MultiZone: &client.MultiZone{
+			Enabled: GetBool(true),
+		},
+		Spot: &client.SpotOptions{
+			Enabled:    GetBool(true),
+			Preference: GetString("preferred"),
+		},
+		NodeAffinity: &client.NodeAffinity{
+			Required: &[]client.NodeSelectorRequirement{
+				{
+					Key:      GetString("kubernetes.io/arch"),
+					Operator: GetString("In"),
+					Values:   &[]string{"amd64", "arm64"},
+				},
+			},
+			Preferred: &[]client.PreferredNodeAffinity{
+				{
+					Weight: GetInt(50),
+					MatchExpressions: &[]client.NodeSelectorRequirement{
+						{
+							Key:      GetString("node.kubernetes.io/instance-type"),
+							Operator: GetString("Exists"),
+						},
+					},
+				},
+			},
+		},
+		AutoScaling: &client.AutoScaling{
+			Metric:           GetString("multi"),
+			Target:           GetInt(95),
+			MaxScale:         GetInt(5),
+			MinScale:         GetInt(1),
+			MaxConcurrency:   GetInt(0),
+			ScaleToZeroDelay: GetInt(300),
+			Multi: &[]client.MultiMetric{
+				{
+					Metric: GetString("cpu"),
+					Target: GetInt(60),
+				},
+				{
+					Metric: GetString("memory"),
+					Target: GetInt(80),
+				},
+			},
+		},
+	}
+
+	return &(*unitTestWorkload.Spec.LocalOptions)[0], expectedOptions, flatten
+}
+
+func generateTestKedaAutoScaling() (*client.KedaAutoScaling, *client.KedaAutoScaling, []interface{}) {
+
+	triggerType := "rabbitmq"
+	queueName := "orders"
+	triggerName := queueName + "-queue"
+	metricType := "AverageValue"
+	pollingInterval := 15
+	cooldownPeriod := 120
+
+	flatten := generateFlatTestKedaAutoScaling(triggerType, queueName, pollingInterval, cooldownPeriod)
+	keda := buildKedaAutoScaling(flatten)
+
+	metadata := map[string]interface{}{
+		"queueName": queueName,
+	}
+
+	expectedKeda := &client.KedaAutoScaling{
+		PollingInterval: &pollingInterval,
+		CooldownPeriod:  &cooldownPeriod,
+		Triggers: &[]client.KedaTrigger{
+			{
+				Type:             &triggerType,
+				Name:             &triggerName,
+				Metadata:         &metadata,
+				MetricType:       &metricType,
+				UseCachedMetrics: GetBool(false),
+			},
+		},
+	}
+
+	return keda, expectedKeda, flatten
+}
+
+func generateTestInitContainers() (*[]client.ContainerSpec, *[]client.ContainerSpec, []interface{}) {
+
+	flatten := []interface{}{
+		map[string]interface{}{
+			"name":    "migrate",
+			"image":   "migrate/migrate:v4.17.0",
+			"command": "migrate",
+			"args":    []interface{}{"-path", "/migrations", "up"},
+			"env": map[string]interface{}{
+				"DATABASE_HOST": "postgres",
+				"DATABASE_PORT": "5432",
+			},
+			"volume": []interface{}{
+				map[string]interface{}{
+					"uri":             "s3://migrations",
+					"recovery_policy": "retain",
+					"path":            "/migrations",
+				},
+			},
+		},
+		map[string]interface{}{
+			"name":    "render-config",
+			"image":   "busybox",
+			"command": "",
+			"args":    []interface{}{},
+			"env":     map[string]interface{}{},
+			"volume":  []interface{}{},
+		},
+	}
+
+	initContainers := buildInitContainers(flatten)
+	expectedInitContainers := &[]client.ContainerSpec{
+		{
+			Name:    GetString("migrate"),
+			Image:   GetString("migrate/migrate:v4.17.0"),
+			Command: GetString("migrate"),
+			Args:    &[]string{"-path", "/migrations", "up"},
+			Env: &[]client.NameValue{
+				{
+					Name:  GetString("DATABASE_HOST"),
+					Value: GetString("postgres"),
+				},
+				{
+					Name:  GetString("DATABASE_PORT"),
+					Value: GetString("5432"),
+				},
+			},
+			Volumes: &[]client.VolumeSpec{
+				{
+					Uri:            GetString("s3://migrations"),
+					RecoveryPolicy: GetString("retain"),
+					Path:           GetString("/migrations"),
+				},
+			},
+		},
+		{
+			Name:  GetString("render-config"),
+			Image: GetString("busybox"),
+		},
+	}
+
+	expectedFlatten := []interface{}{
+		map[string]interface{}{
+			"name":    "migrate",
+			"image":   "migrate/migrate:v4.17.0",
+			"command": "migrate",
+			"args":    []string{"-path", "/migrations", "up"},
+			"env": map[string]interface{}{
+				"DATABASE_HOST": "postgres",
+				"DATABASE_PORT": "5432",
+			},
+			"volume": []interface{}{
+				map[string]interface{}{
+					"uri":             "s3://migrations",
+					"recovery_policy": "retain",
+					"path":            "/migrations",
+				},
+			},
+		},
+		map[string]interface{}{
+			"name":  "render-config",
+			"image": "busybox",
+		},
+	}
+
+	return initContainers, expectedInitContainers, expectedFlatten
+}
+
+func generateTestEnvFrom() ([]client.NameValue, []client.NameValue, []interface{}) {
+
+	flatten := []interface{}{
+		map[string]interface{}{
+			"name": "DB_PASSWORD",
+			"secret": []interface{}{
+				map[string]interface{}{
+					"name": "db",
+					"key":  "password",
+				},
+			},
+			"gvc_env":            []interface{}{},
+			"workload_reference": []interface{}{},
+		},
+		map[string]interface{}{
+			"name": "API_TOKEN",
+			"secret": []interface{}{
+				map[string]interface{}{
+					"name": "api",
+					"key":  "",
+				},
+			},
+			"gvc_env":            []interface{}{},
+			"workload_reference": []interface{}{},
+		},
+		map[string]interface{}{
+			"name":   "REGION",
+			"secret": []interface{}{},
+			"gvc_env": []interface{}{
+				map[string]interface{}{
+					"name": "REGION",
+				},
+			},
+			"workload_reference": []interface{}{},
+		},
+		map[string]interface{}{
+			"name":    "LOCATION",
+			"secret":  []interface{}{},
+			"gvc_env": []interface{}{},
+			"workload_reference": []interface{}{
+				map[string]interface{}{
+					"field": "location",
+				},
+			},
+		},
+	}
+
+	envFrom := buildEnvFrom(flatten)
+	expectedEnvFrom := []client.NameValue{
+		{
+			Name:  GetString("DB_PASSWORD"),
+			Value: GetString("cpln://secret/db.password"),
+		},
+		{
+			Name:  GetString("API_TOKEN"),
+			Value: GetString("cpln://secret/api"),
+		},
+		{
+			Name:  GetString("REGION"),
+			Value: GetString("$(REGION)"),
+		},
+		{
+			Name:  GetString("LOCATION"),
+			Value: GetString("$(CPLN_LOCATION)"),
+		},
+	}
+
+	// Flattening only sets the source that is in use
+	expectedFlatten := []interface{}{
+		map[string]interface{}{
+			"name": "DB_PASSWORD",
+			"secret": []interface{}{
+				map[string]interface{}{
+					"name": "db",
+					"key":  "password",
+				},
+			},
+		},
+		map[string]interface{}{
+			"name": "API_TOKEN",
+			"secret": []interface{}{
+				map[string]interface{}{
+					"name": "api",
+				},
+			},
+		},
+		map[string]interface{}{
+			"name": "REGION",
+			"gvc_env": []interface{}{
+				map[string]interface{}{
+					"name": "REGION",
+				},
+			},
+		},
+		map[string]interface{}{
+			"name": "LOCATION",
+			"workload_reference": []interface{}{
+				map[string]interface{}{
+					"field": "location",
+				},
+			},
+		},
+	}
+
+	return envFrom, expectedEnvFrom, expectedFlatten
+}
+
+func generateTestTypedVolumes() ([]client.VolumeSpec, []client.VolumeSpec, map[string]interface{}) {
+
+	flatten := map[string]interface{}{
+		"name": "container-01",
+		"secret_volume": []interface{}{
+			map[string]interface{}{
+				"secret_link": "/org/unit-test-org/secret/tls",
+				"path":        "/etc/tls",
+				"items": []interface{}{
+					map[string]interface{}{
+						"key":  "cert",
+						"path": "tls.crt",
+					},
+				},
+			},
+		},
+		"volumeset_volume": []interface{}{
+			map[string]interface{}{
+				"volumeset_link": "data",
+				"path":           "/data",
+			},
+		},
+		"s3_volume": []interface{}{
+			map[string]interface{}{
+				"bucket": "assets",
+				"path":   "/assets",
+			},
+		},
+		"gcs_volume": []interface{}{
+			map[string]interface{}{
+				"bucket": "models",
+				"path":   "/models",
+			},
+		},
+		"azure_blob_volume": []interface{}{
+			map[string]interface{}{
+				"storage_account": "account",
+				"container":       "reports",
+				"path":            "/reports",
+			},
+		},
+		"scratch_volume": []interface{}{
+			map[string]interface{}{
+				"name": "config",
+				"path": "/etc/app",
+			},
+		},
+	}
+
+	volumes := buildTypedVolumes(flatten)
+	expectedVolumes := []client.VolumeSpec{
+		{
+			Uri:  GetString("cpln://secret/tls"),
+			Path: GetString("/etc/tls"),
+			Items: &[]client.VolumeItem{
+				{
+					Key:  GetString("cert"),
+					Path: GetString("tls.crt"),
+				},
+			},
+		},
+		{
+			Uri:  GetString("cpln://volumeset/data"),
+			Path: GetString("/data"),
+		},
+		{
+			Uri:  GetString("s3://assets"),
+			Path: GetString("/assets"),
+		},
+		{
+			Uri:  GetString("gs://models"),
+			Path: GetString("/models"),
+		},
+		{
+			Uri:  GetString("azureblob://account/reports"),
+			Path: GetString("/reports"),
+		},
+		{
+			Uri:  GetString("scratch://config"),
+			Path: GetString("/etc/app"),
+		},
+	}
+
+	// Links are flattened back to names, the difference is suppressed by DiffSuppressLinkName
+	flatten["secret_volume"].([]interface{})[0].(map[string]interface{})["secret_link"] = "tls"
+
+	return volumes, expectedVolumes, flatten
+}
+
 func generateTestFirewallSpec(workloadType string) *client.FirewallSpec {
 
 	if workloadType == "cron" {
@@ -2216,6 +3666,138 @@ func generateTestWorkloadSidecar(stringifiedJson string) (*client.WorkloadSideca
 	return sidecar, expectedSidecar, flatten
 }
 
+func generateTestWorkloadTraffic() (*client.RequestRetryPolicy, *[]client.WorkloadRoute, *[]client.WorkloadMirror, *client.RequestRetryPolicy, *[]client.WorkloadRoute, *[]client.WorkloadMirror, []interface{}, []interface{}, []interface{}) {
+
+	shadowLink := "/org/unit-test-org/gvc/gvc-01/workload/shadow"
+	betaLink := "/org/unit-test-org/gvc/gvc-01/workload/beta"
+
+	flatRetryPolicy := []interface{}{
+		map[string]interface{}{
+			"attempts": 3,
+			"retry_on": schema.NewSet(schema.HashString, []interface{}{"5xx", "reset"}),
+		},
+	}
+
+	flatRoutes := []interface{}{
+		map[string]interface{}{
+			"prefix":          "/api",
+			"timeout_seconds": 30,
+		},
+		map[string]interface{}{
+			"prefix":        "/",
+			"workload_link": betaLink,
+			"header": []interface{}{
+				map[string]interface{}{
+					"name":  "x-channel",
+					"exact": "beta",
+				},
+			},
+		},
+	}
+
+	flatMirror := []interface{}{
+		map[string]interface{}{
+			"workload_link": shadowLink,
+			"percent":       25.0,
+		},
+	}
+
+	// Build expects every attribute of the schema to be present
+	buildRoutes := []interface{}{
+		map[string]interface{}{
+			"prefix":          "/api",
+			"timeout_seconds": 30,
+			"workload_link":   "",
+			"header":          []interface{}{},
+		},
+		map[string]interface{}{
+			"prefix":          "/",
+			"timeout_seconds": 0,
+			"workload_link":   betaLink,
+			"header": []interface{}{
+				map[string]interface{}{
+					"name":   "x-channel",
+					"exact":  "beta",
+					"prefix": "",
+					"regex":  "",
+				},
+			},
+		},
+	}
+
+	retryPolicy := buildRequestRetryPolicy(flatRetryPolicy)
+	routes := buildWorkloadRoutes(buildRoutes)
+	mirror := buildWorkloadMirror(flatMirror)
+
+	expectedRetryPolicy := &client.RequestRetryPolicy{
+		Attempts: GetInt(3),
+		RetryOn:  &[]string{"5xx", "reset"},
+	}
+
+	expectedRoutes := &[]client.WorkloadRoute{
+		{
+			Prefix:         GetString("/api"),
+			TimeoutSeconds: GetInt(30),
+		},
+		{
+			Prefix:       GetString("/"),
+			WorkloadLink: &betaLink,
+			Headers: &[]client.WorkloadRouteHeaderMatch{
+				{
+					Name:  GetString("x-channel"),
+					Exact: GetString("beta"),
+				},
+			},
+		},
+	}
+
+	expectedMirror := &[]client.WorkloadMirror{
+		{
+			WorkloadLink: &shadowLink,
+			Percent:      GetFloat64(25.0),
+		},
+	}
+
+	return retryPolicy, routes, mirror, expectedRetryPolicy, expectedRoutes, expectedMirror, flatRetryPolicy, flatRoutes, flatMirror
+}
+
+func generateTestWorkloadLoadBalancer() (*client.WorkloadLoadBalancer, *client.WorkloadLoadBalancer, []interface{}) {
+
+	enabled := true
+	externalPort := 1883
+	protocol := "TCP"
+	scheme := "tcp"
+	containerPort := 8080
+	geoEnabled := true
+	country := "x-geo-country"
+	region := "x-geo-region"
+
+	flatten := generateFlatTestWorkloadLoadBalancer(enabled, externalPort, protocol, scheme, containerPort, geoEnabled, country, region)
+	loadBalancer := buildWorkloadLoadBalancer(flatten)
+	expectedLoadBalancer := &client.WorkloadLoadBalancer{
+		Direct: &client.DirectLoadBalancer{
+			Enabled: &enabled,
+			Ports: &[]client.DirectLoadBalancerPort{
+				{
+					ExternalPort:  &externalPort,
+					Protocol:      &protocol,
+					Scheme:        &scheme,
+					ContainerPort: &containerPort,
+				},
+			},
+		},
+		GeoLocation: &client.GeoLocation{
+			Enabled: &geoEnabled,
+			Headers: &client.GeoLocationHeaders{
+				Country: &country,
+				Region:  &region,
+			},
+		},
+	}
+
+	return loadBalancer, expectedLoadBalancer, flatten
+}
+
 // Flatten //
 func generateFlatTestContainer(workloadType string) []interface{} {
 
@@ -2429,6 +4011,93 @@ func generateFlatTestOptions() []interface{} {
 	}
 }
 
+func generateFlatTestOptionsScheduling(location string) []interface{} {
+
+	autoScaling := map[string]interface{}{
+		"metric":              "multi",
+		"target":              95,
+		"max_scale":           5,
+		"min_scale":           1,
+		"max_concurrency":     0,
+		"scale_to_zero_delay": 300,
+		"multi": []interface{}{
+			map[string]interface{}{
+				"metric": "cpu",
+				"target": 60,
+			},
+			map[string]interface{}{
+				"metric": "memory",
+				"target": 80,
+			},
+		},
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"location":        location,
+			"capacity_ai":     false,
+			"timeout_seconds": 30,
+			"debug":           false,
+			"suspend":         false,
+			"autoscaling":     []interface{}{autoScaling},
+			"multi_zone": []interface{}{
+				map[string]interface{}{
+					"enabled": true,
+				},
+			},
+			"spot": []interface{}{
+				map[string]interface{}{
+					"enabled":    true,
+					"preference": "preferred",
+				},
+			},
+			"node_affinity": []interface{}{
+				map[string]interface{}{
+					"required": []interface{}{
+						map[string]interface{}{
+							"key":      "kubernetes.io/arch",
+							"operator": "In",
+							"values":   []interface{}{"amd64", "arm64"},
+						},
+					},
+					"preferred": []interface{}{
+						map[string]interface{}{
+							"weight": 50,
+							"match_expression": []interface{}{
+								map[string]interface{}{
+									"key":      "node.kubernetes.io/instance-type",
+									"operator": "Exists",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateFlatTestKedaAutoScaling(triggerType string, queueName string, pollingInterval int, cooldownPeriod int) []interface{} {
+
+	return []interface{}{
+		map[string]interface{}{
+			"polling_interval": pollingInterval,
+			"cooldown_period":  cooldownPeriod,
+			"trigger": []interface{}{
+				map[string]interface{}{
+					"type":        triggerType,
+					"name":        queueName + "-queue",
+					"metric_type": "AverageValue",
+					"metadata": map[string]interface{}{
+						"queueName": queueName,
+					},
+					"use_cached_metrics": false,
+				},
+			},
+		},
+	}
+}
+
 func generateFlatTestFirewallSpec(useSet bool) []interface{} {
 
 	stringFunc := schema.HashSchema(StringSchema())
@@ -2550,3 +4219,36 @@ func generateFlatTestWorkloadSidecar(envoy string) []interface{} {
 		spec,
 	}
 }
+
+func generateFlatTestWorkloadLoadBalancer(enabled bool, externalPort int, protocol string, scheme string, containerPort int, geoEnabled bool, country string, region string) []interface{} {
+	spec := map[string]interface{}{
+		"direct": []interface{}{
+			map[string]interface{}{
+				"enabled": enabled,
+				"ports": []interface{}{
+					map[string]interface{}{
+						"external_port":  externalPort,
+						"protocol":       protocol,
+						"scheme":         scheme,
+						"container_port": containerPort,
+					},
+				},
+			},
+		},
+		"geo_location": []interface{}{
+			map[string]interface{}{
+				"enabled": geoEnabled,
+				"headers": []interface{}{
+					map[string]interface{}{
+						"country": country,
+						"region":  region,
+					},
+				},
+			},
+		},
+	}
+
+	return []interface{}{
+		spec,
+	}
+}